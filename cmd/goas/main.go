@@ -2,6 +2,7 @@ package main
 
 import (
 	"flag"
+	"fmt"
 	"log/slog"
 	"os"
 	"strings"
@@ -10,38 +11,146 @@ import (
 )
 
 func main() {
-	// 1. 变量定义： 扫描的目录或者文件(多个目录使用逗号分隔的字符串： "./a,./b")/输出文件路径
-	var dir, output string
+	// 0. 子命令: goas check 只校验注释，不生成文件
+	args := os.Args[1:]
+	checkOnly := len(args) > 0 && args[0] == "check"
+	if checkOnly {
+		args = args[1:]
+	}
+
+	// 1. 变量定义： 扫描的目录或者文件(多个目录使用逗号分隔的字符串： "./a,./b")/输出文件路径/输出格式
+	var configPath, dir, output, format, pathOrder, naming, genericNaming, required, nullable, operationIDConflict, globalPackage, customMethods string
+	var strict, embedAllOf, discoverRoutes, inferParams, autoPathParams bool
 
 	// 2. 变量绑定
+	flag.StringVar(&configPath, "config", "", "JSON 配置文件路径，可在其中定义多份文档 (specs)，命令行中显式指定的参数优先")
 	flag.StringVar(&dir, "dir", "", "扫描的目录，多个目录使用逗号分隔")
 	flag.StringVar(&output, "output", "./api", "输出文件路径")
+	flag.StringVar(&format, "format", "json", "输出格式，支持 json,yaml，多个格式使用逗号分隔")
+	flag.StringVar(&pathOrder, "path-order", goas.PathOrderSource, "路径输出顺序: source (源码顺序) 或 alpha (字母序)")
+	flag.StringVar(&genericNaming, "generic-naming", goas.GenericNamingOf, "泛型实例命名方式: of (ResponseOfUser)、underscore (Response_User)、concat (ResponseUser) 或 guillemet (Response«User»，不符合 OpenAPI 键名规则)")
+	flag.StringVar(&naming, "naming", goas.NamingShort, "Schema 命名策略: short (类型名，冲突时加包名)、package (包名.类型名) 或 full (完整包路径)")
+
+	flag.StringVar(&required, "required", goas.RequiredDefault, "必填字段判定: default (无 omitempty/omitzero 的非指针字段)、omitempty (无 omitempty/omitzero 的字段) 或 validate (仅校验规则中的 required)")
+	flag.StringVar(&nullable, "nullable", goas.NullablePointer, "可为 null 的字段: pointer (指针字段) 或 none")
+	flag.StringVar(&globalPackage, "global-package", "", "全局注解所在的包路径，多个包都有全局注解时以该包为准")
+	flag.StringVar(&customMethods, "custom-methods", "", "允许的自定义 HTTP 方法 (如 purge)，多个方法使用逗号分隔")
+	flag.StringVar(&operationIDConflict, "operation-id-conflict", goas.OperationIDReport, "重复 OperationID 的处理方式: report (仅报告)、package (加包名前缀) 或 tag (加标签前缀)")
+
+	flag.BoolVar(&strict, "strict", false, "严格模式: 发现任何注释问题时不生成文件并以非零状态退出")
+	flag.BoolVar(&discoverRoutes, "discover-routes", false, "从路由注册代码 (gin、chi、echo、net/http ServeMux) 中发现路由，没有 @Router 的处理函数使用发现的路由")
+	flag.BoolVar(&inferParams, "infer-params", false, "从处理函数体中的 gin 绑定调用推断缺少的参数和请求体")
+	flag.BoolVar(&autoPathParams, "auto-path-params", false, "为路径模板中缺少 @Param 声明的参数自动生成 string 类型的 path 参数")
+	flag.BoolVar(&embedAllOf, "embed-allof", false, "嵌入结构体使用 allOf 引用基础模型，而不是展开其字段")
 
 	// 3. 解析命令行参数
-	flag.Parse()
+	_ = flag.CommandLine.Parse(args)
 
-	// 4.参数校验
-	if dir == "" {
+	// 4. 读取配置文件
+	cfg := goas.Config{}
+	if configPath != "" {
+		var err error
+		if cfg, err = goas.LoadConfig(configPath); err != nil {
+			slog.Error("读取配置文件失败", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	// 5. 命令行参数覆盖配置文件: 没有配置文件时使用所有参数 (包括默认值)，否则只使用显式指定的参数
+	set := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	override := func(name string) bool { return configPath == "" || set[name] }
+
+	if override("dir") {
+		cfg.Dirs = splitList(dir) // 逗号分隔参数
+	}
+	if override("output") {
+		cfg.Output = output
+	}
+	if override("format") {
+		cfg.Formats = splitList(format)
+	}
+	if override("path-order") {
+		cfg.PathOrder = pathOrder
+	}
+	if override("strict") {
+		cfg.Strict = strict
+	}
+	if override("embed-allof") {
+		cfg.EmbedAllOf = embedAllOf
+	}
+	if override("naming") {
+		cfg.Naming = naming
+	}
+	if override("generic-naming") {
+		cfg.GenericNaming = genericNaming
+	}
+	if override("required") {
+		cfg.Required = required
+	}
+	if override("nullable") {
+		cfg.Nullable = nullable
+	}
+	if override("discover-routes") {
+		cfg.DiscoverRoutes = discoverRoutes
+	}
+	if override("infer-params") {
+		cfg.InferParams = inferParams
+	}
+	if override("operation-id-conflict") {
+		cfg.OperationIDConflict = operationIDConflict
+	}
+	if override("auto-path-params") {
+		cfg.AutoPathParams = autoPathParams
+	}
+	if override("global-package") {
+		cfg.GlobalPackage = globalPackage
+	}
+	if override("custom-methods") {
+		cfg.CustomMethods = splitList(customMethods)
+	}
+	if cfg.Output == "" {
+		cfg.Output = "./api"
+	}
+
+	// 6.参数校验
+	if len(cfg.Dirs) == 0 {
 		slog.Error("参数错误: 需要指定扫描目录或者文件路径")
 		os.Exit(1)
 	}
 
-	// 5. 逗号分隔参数
-	var dirs []string
-	for _, s := range strings.Split(dir, ",") {
-		s = strings.TrimSpace(s) // 去掉空格
-		if s != "" {
-			dirs = append(dirs, s)
+	// 7. check 模式: 输出诊断信息，存在问题时以非零状态退出
+	if checkOnly {
+		diags, err := goas.Check(cfg)
+		if err != nil {
+			slog.Error("执行失败", "error", err)
+			os.Exit(1)
+		}
+		for _, d := range diags {
+			fmt.Fprintln(os.Stderr, d)
 		}
+		if len(diags) > 0 {
+			slog.Error("注释校验未通过", "problems", len(diags))
+			os.Exit(1)
+		}
+		fmt.Println("注释校验通过")
+		return
 	}
 
-	// 6. 调用库函数
-	cfg := goas.Config{
-		Dirs:   dirs,
-		Output: output,
-	}
 	if err := goas.Run(cfg); err != nil {
 		slog.Error("执行失败", "error", err)
 		os.Exit(1)
 	}
 }
+
+// splitList 拆分逗号分隔的参数并去掉空白项
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item) // 去掉空格
+		if item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -16,6 +16,11 @@ import "fmt"
 //
 // @Server http://localhost:8081/
 //
+// @Tag.Name Auth
+// @Tag.Desc 认证
+// @Tag.Name user
+// @Tag.Desc 用户管理
+//
 // @SecurityScheme BearerAuth apiKey header Authorization
 // @SecurityScheme CookieAuth apiKey header Cookie
 func main() {
@@ -27,7 +27,7 @@ func Login() {
 // @Param size query int false "每页数量"
 // @Param keyword query string false "搜索关键词"
 // @Success 200 {object} model.Response[model.PageList[model.User]] "成功"
-// @Failure 400 {object} ErrorResponse "参数错误"
+// @Failure 400 {object} model.ErrorResponse "参数错误"
 // @Router /users [get]
 func ListUsers() {
 	fmt.Println("list users handler")
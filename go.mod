@@ -2,8 +2,9 @@ module github.com/promonkeyli/goas
 
 go 1.25.3
 
+require golang.org/x/tools v0.40.0
+
 require (
 	golang.org/x/mod v0.31.0 // indirect
 	golang.org/x/sync v0.19.0 // indirect
-	golang.org/x/tools v0.40.0 // indirect
 )
@@ -9,7 +9,19 @@ import (
 	"github.com/promonkeyli/goas/pkg/model"
 )
 
-func GenFiles(openAPI *model.T, outputPath string) error {
+// 支持的输出格式
+const (
+	FormatJSON = "json"
+	FormatYAML = "yaml"
+)
+
+// GenFiles 生成 OpenAPI 文档文件
+// formats 为空时默认只生成 openapi.json
+func GenFiles(openAPI *model.T, outputPath string, formats ...string) error {
+	if len(formats) == 0 {
+		formats = []string{FormatJSON}
+	}
+
 	// 1. 确保输出目录存在
 	if _, err := os.Stat(outputPath); os.IsNotExist(err) {
 		// 创建目录，权限 0755
@@ -20,20 +32,40 @@ func GenFiles(openAPI *model.T, outputPath string) error {
 
 	// 2. 生成 JSON 内容
 	// 使用 MarshalIndent 可以在输出时进行格式化（带缩进），方便阅读
+	// YAML 也基于 JSON 结果转换，保证两种格式的键顺序一致
 	jsonBytes, err := json.MarshalIndent(openAPI, "", "  ")
 	if err != nil {
 		return fmt.Errorf("序列化 JSON 失败: %w", err)
 	}
 
-	// 3. 定义文件路径
-	jsonPath := filepath.Join(outputPath, "openapi.json")
-
-	// 4. 写入 JSON 文件
+	// 3. 按格式写入文件
 	// 0644 代表文件所有者可读写，其他人可读
-	if err := os.WriteFile(jsonPath, jsonBytes, 0644); err != nil {
-		return fmt.Errorf("写入 JSON 文件失败: %w", err)
+	for _, format := range formats {
+		var (
+			data     []byte
+			fileName string
+		)
+
+		switch format {
+		case FormatJSON:
+			data = jsonBytes
+			fileName = "openapi.json"
+		case FormatYAML, "yml":
+			data, err = jsonToYAML(jsonBytes)
+			if err != nil {
+				return fmt.Errorf("序列化 YAML 失败: %w", err)
+			}
+			fileName = "openapi.yaml"
+		default:
+			return fmt.Errorf("不支持的输出格式: %s", format)
+		}
+
+		filePath := filepath.Join(outputPath, fileName)
+		if err := os.WriteFile(filePath, data, 0644); err != nil {
+			return fmt.Errorf("写入文件失败: %w", err)
+		}
+		fmt.Printf("生成成功: %s\n", filePath)
 	}
-	fmt.Printf("生成成功: %s\n", jsonPath)
 
 	return nil
 }
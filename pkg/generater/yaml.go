@@ -0,0 +1,215 @@
+package generater
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// orderedObject 保留键顺序的 JSON 对象
+type orderedObject struct {
+	keys   []string
+	values []any
+}
+
+// jsonToYAML 将 JSON 文档转换为 YAML
+// 直接基于 JSON token 流构建，因此键顺序与 JSON 输出完全一致，
+// 并且 model 中自定义 MarshalJSON 的类型 (Paths, Responses, Callback) 也能被正确处理
+func jsonToYAML(data []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	root, err := decodeValue(dec)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeYAMLValue(&buf, root, 0)
+	if buf.Len() == 0 || buf.Bytes()[buf.Len()-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeValue 从 token 流中读取一个完整的 JSON 值
+func decodeValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+
+	switch delim {
+	case '{':
+		obj := &orderedObject{}
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := keyTok.(string)
+			if !ok {
+				return nil, fmt.Errorf("非法的对象键: %v", keyTok)
+			}
+			val, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj.keys = append(obj.keys, key)
+			obj.values = append(obj.values, val)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	case '[':
+		arr := []any{}
+		for dec.More() {
+			val, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, val)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return arr, nil
+	default:
+		return nil, fmt.Errorf("非法的 JSON 分隔符: %v", delim)
+	}
+}
+
+// writeYAMLValue 以块格式写出一个值 (调用方负责写好当前行的前缀)
+func writeYAMLValue(w io.Writer, v any, indent int) {
+	switch val := v.(type) {
+	case *orderedObject:
+		if len(val.keys) == 0 {
+			fmt.Fprint(w, "{}\n")
+			return
+		}
+		for i, key := range val.keys {
+			if i > 0 {
+				fmt.Fprint(w, strings.Repeat(" ", indent))
+			}
+			fmt.Fprintf(w, "%s:", formatYAMLString(key))
+			writeYAMLChild(w, val.values[i], indent)
+		}
+	case []any:
+		if len(val) == 0 {
+			fmt.Fprint(w, "[]\n")
+			return
+		}
+		for i, item := range val {
+			if i > 0 {
+				fmt.Fprint(w, strings.Repeat(" ", indent))
+			}
+			fmt.Fprint(w, "- ")
+			// 数组项内的集合紧跟在 "- " 后面，后续行缩进 2 格，空集合写作 {} 或 []
+			writeYAMLValue(w, item, indent+2)
+		}
+	default:
+		writeYAMLScalar(w, val, indent)
+	}
+}
+
+// writeYAMLChild 写出对象中某个键对应的值 (冒号之后的部分)
+func writeYAMLChild(w io.Writer, v any, indent int) {
+	switch val := v.(type) {
+	case *orderedObject:
+		if len(val.keys) == 0 {
+			fmt.Fprint(w, " {}\n")
+			return
+		}
+		fmt.Fprintf(w, "\n%s", strings.Repeat(" ", indent+2))
+		writeYAMLValue(w, val, indent+2)
+	case []any:
+		if len(val) == 0 {
+			fmt.Fprint(w, " []\n")
+			return
+		}
+		// 数组项与父级键保持同一缩进 (YAML 常见风格)
+		fmt.Fprintf(w, "\n%s", strings.Repeat(" ", indent))
+		writeYAMLValue(w, val, indent)
+	default:
+		fmt.Fprint(w, " ")
+		writeYAMLScalar(w, val, indent+2)
+	}
+}
+
+// writeYAMLScalar 写出标量值并换行
+func writeYAMLScalar(w io.Writer, v any, indent int) {
+	switch val := v.(type) {
+	case nil:
+		fmt.Fprint(w, "null\n")
+	case bool:
+		fmt.Fprintf(w, "%t\n", val)
+	case json.Number:
+		fmt.Fprintf(w, "%s\n", val.String())
+	case string:
+		if isBlockString(val) {
+			// 多行文本使用字面块，保持可读性
+			fmt.Fprint(w, "|-\n")
+			pad := strings.Repeat(" ", indent)
+			for _, line := range strings.Split(val, "\n") {
+				if line == "" {
+					fmt.Fprint(w, "\n")
+					continue
+				}
+				fmt.Fprintf(w, "%s%s\n", pad, line)
+			}
+			return
+		}
+		fmt.Fprintf(w, "%s\n", formatYAMLString(val))
+	default:
+		fmt.Fprintf(w, "%v\n", val)
+	}
+}
+
+// isBlockString 判断字符串是否适合使用字面块 (|-) 输出
+func isBlockString(s string) bool {
+	if !strings.Contains(s, "\n") || strings.HasSuffix(s, "\n") {
+		return false
+	}
+	if strings.HasPrefix(s, " ") || strings.HasPrefix(s, "\n") || strings.ContainsAny(s, "\r\t") {
+		return false
+	}
+	for _, line := range strings.Split(s, "\n") {
+		if strings.HasSuffix(line, " ") {
+			return false
+		}
+	}
+	return true
+}
+
+// plainYAMLString 可以不加引号直接输出的字符串
+var plainYAMLString = regexp.MustCompile(`^[\p{L}_$/][\p{L}\p{N}_$/.\-+ ]*$`)
+
+// yamlReserved 在 YAML 中会被解析为非字符串的字面量
+var yamlReserved = map[string]bool{
+	"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true,
+	"y": true, "n": true, "null": true, "~": true,
+}
+
+// formatYAMLString 格式化字符串，必要时使用双引号
+// JSON 的字符串转义规则是 YAML 双引号字符串的子集，因此直接复用 JSON 编码
+func formatYAMLString(s string) string {
+	if plainYAMLString.MatchString(s) && !strings.HasSuffix(s, " ") &&
+		!yamlReserved[strings.ToLower(s)] {
+		return s
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,59 @@
+package generater
+
+import "testing"
+
+func TestJSONToYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{
+			name: "保持键顺序",
+			json: `{"openapi":"3.2.0","info":{"title":"API","version":"1.0"},"paths":{}}`,
+			want: "openapi: \"3.2.0\"\ninfo:\n  title: API\n  version: \"1.0\"\npaths: {}\n",
+		},
+		{
+			name: "数组与嵌套对象",
+			json: `{"tags":["a","b"],"servers":[{"url":"/v1","name":"dev"}],"empty":[]}`,
+			want: "tags:\n- a\n- b\nservers:\n- url: /v1\n  name: dev\nempty: []\n",
+		},
+		{
+			name: "标量",
+			json: `{"x":1.5,"b":true,"z":null,"neg":-1}`,
+			want: "x: 1.5\nb: true\nz: null\nneg: -1\n",
+		},
+		{
+			name: "需要引号的字符串",
+			json: `{"n":"n","yes":"yes","num":"123","colon":"a: b","empty":"","ref":"#/components/schemas/User","trailing":"a "}`,
+			want: "\"n\": \"n\"\n\"yes\": \"yes\"\nnum: \"123\"\ncolon: \"a: b\"\nempty: \"\"\nref: \"#/components/schemas/User\"\ntrailing: \"a \"\n",
+		},
+		{
+			name: "数组中的空集合",
+			json: `{"security":[{}],"matrix":[[],[1]]}`,
+			want: "security:\n- {}\nmatrix:\n- []\n- - 1\n",
+		},
+		{
+			name: "多行文本使用字面块",
+			json: `{"description":"第一行\n\n第三行"}`,
+			want: "description: |-\n  第一行\n\n  第三行\n",
+		},
+		{
+			name: "不适合字面块的多行文本",
+			json: `{"description":"末尾换行\n"}`,
+			want: "description: \"末尾换行\\n\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := jsonToYAML([]byte(tt.json))
+			if err != nil {
+				t.Fatalf("jsonToYAML() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("jsonToYAML() =\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
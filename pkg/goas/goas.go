@@ -3,38 +3,246 @@ package goas
 import (
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/promonkeyli/goas/pkg/generater"
+	"github.com/promonkeyli/goas/pkg/model"
 	"github.com/promonkeyli/goas/pkg/parser"
 )
 
+// Path output orders
+const (
+	// PathOrderSource keeps paths in the order they appear in source code (default)
+	PathOrderSource = "source"
+	// PathOrderAlpha sorts paths alphabetically
+	PathOrderAlpha = "alpha"
+)
+
+// Component schema naming strategies
+const (
+	// NamingShort names schemas by type name (Invoice), qualifying them only on collision (default)
+	NamingShort = parser.NamingShort
+	// NamingPackage names schemas by package-qualified type name (billing.Invoice)
+	NamingPackage = parser.NamingPackage
+	// NamingFull names schemas by full import path (github.com.acme.billing.Invoice)
+	NamingFull = parser.NamingFull
+)
+
+// Generic instantiation naming styles
+const (
+	// GenericNamingOf ResponseOfUser (default)
+	GenericNamingOf = parser.GenericNamingOf
+	// GenericNamingUnderscore Response_User
+	GenericNamingUnderscore = parser.GenericNamingUnderscore
+	// GenericNamingConcat ResponseUser
+	GenericNamingConcat = parser.GenericNamingConcat
+	// GenericNamingGuillemet Response«User». The names do not match the OpenAPI component key
+	// pattern ^[a-zA-Z0-9.\-_]+$, so some tools may reject them
+	GenericNamingGuillemet = parser.GenericNamingGuillemet
+)
+
+// Required field policies
+const (
+	// RequiredDefault non-pointer fields without omitempty/omitzero are required (default)
+	RequiredDefault = parser.RequiredDefault
+	// RequiredOmitEmpty all fields without omitempty/omitzero are required, including pointers
+	RequiredOmitEmpty = parser.RequiredOmitEmpty
+	// RequiredValidate only fields with a validate/binding "required" rule are required
+	RequiredValidate = parser.RequiredValidate
+)
+
+// Nullable field policies
+const (
+	// NullablePointer pointer fields accept null (default)
+	NullablePointer = parser.NullablePointer
+	// NullableNone never emit null
+	NullableNone = parser.NullableNone
+)
+
+// OperationId conflict handling
+const (
+	// OperationIDReport only reports duplicate operationIds (default)
+	OperationIDReport = parser.OperationIDReport
+	// OperationIDPackage prefixes duplicate operationIds with the package name (user_List)
+	OperationIDPackage = parser.OperationIDPackage
+	// OperationIDTag prefixes duplicate operationIds with the first tag, falling back to the package name (Users_List)
+	OperationIDTag = parser.OperationIDTag
+)
+
 // Config Configuration for the generator
 type Config struct {
 	// Dirs Directories to scan for comments. e.g. ["./cmd", "./internal"]
-	Dirs []string
+	Dirs []string `json:"dirs"`
 	// Output Output directory path. e.g. "./api"
-	Output string
+	Output string `json:"output,omitempty"`
+	// Formats Output formats, "json" and/or "yaml". Defaults to ["json"]
+	Formats []string `json:"formats,omitempty"`
+	// PathOrder Order of paths in the output, PathOrderSource (default) or PathOrderAlpha
+	PathOrder string `json:"pathOrder,omitempty"`
+	// Strict Fail without generating files when any annotation problem is found
+	Strict bool `json:"strict,omitempty"`
+	// EmbedAllOf Reference embedded structs via allOf instead of flattening their promoted fields
+	EmbedAllOf bool `json:"embedAllOf,omitempty"`
+	// TypeOverrides Schemas for specific types, keyed by fully qualified type name.
+	// e.g. {"github.com/acme/money.Amount": {Type: "string", Format: "decimal"}}
+	// Overrides take precedence over the built-in well-known type mappings.
+	TypeOverrides map[string]*model.Schema `json:"typeOverrides,omitempty"`
+	// Naming Component schema naming strategy, NamingShort (default), NamingPackage or NamingFull
+	Naming string `json:"naming,omitempty"`
+	// GenericNaming Built-in naming style for generic instantiations, e.g. GenericNamingUnderscore.
+	// Defaults to GenericNamingOf
+	GenericNaming string `json:"genericNaming,omitempty"`
+	// GenericNamer Custom naming hook for generic instantiations, takes precedence over GenericNaming.
+	// It receives the base type name and the already named type arguments,
+	// e.g. Response[PageList[User]] is named as GenericNamer("Response", []string{GenericNamer("PageList", []string{"User"})}).
+	// Names that break the OpenAPI component key pattern are reported as warnings
+	GenericNamer func(base string, args []string) string `json:"-"`
+	// Required Policy deciding which struct fields are required, RequiredDefault (default), RequiredOmitEmpty or RequiredValidate
+	Required string `json:"required,omitempty"`
+	// Nullable Policy deciding which struct fields accept null, NullablePointer (default) or NullableNone
+	Nullable string `json:"nullable,omitempty"`
+	// DiscoverRoutes Discover routes from router registrations (gin, chi, echo, net/http ServeMux)
+	// and use them for annotated handlers without @Router
+	DiscoverRoutes bool `json:"discoverRoutes,omitempty"`
+	// RouteExtractors Additional route extractors for other frameworks, tried before the built-in ones.
+	// Only used when DiscoverRoutes is enabled
+	RouteExtractors []parser.RouteExtractor `json:"-"`
+	// InferParams Infer missing parameters and request bodies from gin calls in handler bodies
+	// (c.ShouldBindJSON(&req), c.ShouldBindQuery(&q), c.Param("id"), c.Query("page")).
+	// Explicit @Param annotations always take precedence
+	InferParams bool `json:"inferParams,omitempty"`
+	// OperationIDConflict How duplicate operationIds are handled, OperationIDReport (default),
+	// OperationIDPackage or OperationIDTag
+	OperationIDConflict string `json:"operationIdConflict,omitempty"`
+	// AutoPathParams Create `type: string, required: true` path parameters for template
+	// parameters without a matching @Param instead of only reporting them
+	AutoPathParams bool `json:"autoPathParams,omitempty"`
+	// GlobalPackage Import path of the package whose global annotations (package doc comment,
+	// @GoasGlobal declarations or func main) are authoritative when several packages carry them.
+	// Defaults to the first package with an @GoasGlobal source, then the first func main,
+	// then the first package doc carrying global annotations
+	GlobalPackage string `json:"globalPackage,omitempty"`
+	// CustomMethods Non-standard HTTP methods (e.g. PURGE) accepted without a warning.
+	// Methods without a PathItem field are emitted under additionalOperations
+	CustomMethods []string `json:"customMethods,omitempty"`
+	// Specs Named specs generated from a single scan of Dirs, e.g. a public and an internal spec.
+	// When empty, a single spec is generated from the fields above
+	Specs []Spec `json:"specs,omitempty"`
 }
 
-// Run executes the parsing and generation process
-func Run(cfg Config) error {
+// parserOptions converts the config into parser options
+func (cfg Config) parserOptions() parser.Options {
+	return parser.Options{
+		EmbedAllOf:          cfg.EmbedAllOf,
+		TypeOverrides:       cfg.TypeOverrides,
+		Naming:              cfg.Naming,
+		GenericNaming:       cfg.GenericNaming,
+		GenericNamer:        cfg.GenericNamer,
+		Required:            cfg.Required,
+		Nullable:            cfg.Nullable,
+		DiscoverRoutes:      cfg.DiscoverRoutes,
+		RouteExtractors:     cfg.RouteExtractors,
+		InferParams:         cfg.InferParams,
+		OperationIDConflict: cfg.OperationIDConflict,
+		AutoPathParams:      cfg.AutoPathParams,
+		GlobalPackage:       cfg.GlobalPackage,
+		CustomMethods:       cfg.CustomMethods,
+	}
+}
+
+// validate checks the options that the parser cannot recover from
+func (cfg Config) validate() error {
 	if len(cfg.Dirs) == 0 {
 		return errors.New("dirs cannot be empty")
 	}
+	switch cfg.Naming {
+	case "", NamingShort, NamingPackage, NamingFull:
+	default:
+		return fmt.Errorf("unknown naming strategy: %s", cfg.Naming)
+	}
+	if _, ok := parser.GenericNamers[cfg.GenericNaming]; cfg.GenericNaming != "" && !ok {
+		return fmt.Errorf("unknown generic naming style: %s", cfg.GenericNaming)
+	}
+	switch cfg.Required {
+	case "", RequiredDefault, RequiredOmitEmpty, RequiredValidate:
+	default:
+		return fmt.Errorf("unknown required policy: %s", cfg.Required)
+	}
+	switch cfg.Nullable {
+	case "", NullablePointer, NullableNone:
+	default:
+		return fmt.Errorf("unknown nullable policy: %s", cfg.Nullable)
+	}
+	switch cfg.PathOrder {
+	case "", PathOrderSource, PathOrderAlpha:
+	default:
+		return fmt.Errorf("unknown path order: %s", cfg.PathOrder)
+	}
+	switch cfg.OperationIDConflict {
+	case "", OperationIDReport, OperationIDPackage, OperationIDTag:
+	default:
+		return fmt.Errorf("unknown operationId conflict strategy: %s", cfg.OperationIDConflict)
+	}
+	return nil
+}
+
+// ErrDiagnostics is returned in strict mode when annotation problems were found
+var ErrDiagnostics = errors.New("annotation problems found")
+
+// Run executes the parsing and generation process.
+// All specs are parsed from a single load of Dirs before any file is written
+func Run(cfg Config) error {
+	if err := cfg.validate(); err != nil {
+		return err
+	}
 	if cfg.Output == "" {
 		cfg.Output = "."
 	}
+	if err := cfg.validateSpecs(); err != nil {
+		return err
+	}
 
 	// Parse comments
-	openapi, err := parser.Parse(cfg.Dirs)
+	results, diags, err := cfg.parseSpecs()
 	if err != nil {
-		return fmt.Errorf("parse failed: %w", err)
+		return err
+	}
+
+	// Report annotation problems
+	for _, d := range diags {
+		fmt.Fprintln(os.Stderr, d)
+	}
+	if cfg.Strict && len(diags) > 0 {
+		return fmt.Errorf("%w: %d problem(s)", ErrDiagnostics, len(diags))
 	}
 
-	// Generate file
-	if err := generater.GenFiles(openapi, cfg.Output); err != nil {
-		return fmt.Errorf("generate failed: %w", err)
+	for _, r := range results {
+		// Sort paths
+		if cfg.PathOrder == PathOrderAlpha && r.openapi.Paths != nil {
+			r.openapi.Paths.Paths.Sort(func(a, b string) bool { return a < b })
+		}
+
+		// Generate file
+		if err := generater.GenFiles(r.openapi, r.spec.Output, cfg.Formats...); err != nil {
+			return fmt.Errorf("generate failed: %w", err)
+		}
 	}
 
 	return nil
 }
+
+// Check parses the annotations of every spec without generating files and returns the problems found
+func Check(cfg Config) (parser.Diagnostics, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if err := cfg.validateSpecs(); err != nil {
+		return nil, err
+	}
+
+	_, diags, err := cfg.parseSpecs()
+	if err != nil {
+		return nil, err
+	}
+	return diags, nil
+}
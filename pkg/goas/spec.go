@@ -0,0 +1,153 @@
+package goas
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/promonkeyli/goas/pkg/model"
+	"github.com/promonkeyli/goas/pkg/parser"
+)
+
+// Spec A named spec generated from the shared scan of Config.Dirs.
+// Each spec selects its own global annotations and the operations it includes, e.g.
+//
+//	{"name": "public", "excludeTags": ["internal"]}
+//	{"name": "admin", "globalPackage": "github.com/acme/shop/admin", "packages": ["github.com/acme/shop/admin/..."]}
+type Spec struct {
+	// Name Identifies the spec in diagnostics. Required and unique when Config.Specs is used
+	Name string `json:"name"`
+	// Output Output directory of this spec. Defaults to <Config.Output>/<Name>
+	Output string `json:"output,omitempty"`
+	// GlobalPackage Import path of the package whose global annotations this spec uses.
+	// Defaults to Config.GlobalPackage
+	GlobalPackage string `json:"globalPackage,omitempty"`
+	// Packages Import paths of the packages whose operations are included, "/..." matches all subpackages.
+	// Defaults to all scanned packages
+	Packages []string `json:"packages,omitempty"`
+	// IncludeTags Only include operations carrying at least one of these tags
+	IncludeTags []string `json:"includeTags,omitempty"`
+	// ExcludeTags Drop operations carrying any of these tags, the tags are also removed from the global tag list
+	ExcludeTags []string `json:"excludeTags,omitempty"`
+}
+
+// LoadConfig reads a JSON config file. Relative paths in the file are resolved against
+// the working directory, like the command-line flags
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("read config: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&cfg); err != nil {
+		return cfg, fmt.Errorf("parse config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// specs returns the specs to generate, a single unnamed spec built from the config when Specs is empty
+func (cfg Config) specs() []Spec {
+	if len(cfg.Specs) == 0 {
+		return []Spec{{Output: cfg.Output, GlobalPackage: cfg.GlobalPackage}}
+	}
+
+	specs := make([]Spec, len(cfg.Specs))
+	for i, spec := range cfg.Specs {
+		if spec.Output == "" {
+			spec.Output = filepath.Join(cfg.Output, spec.Name)
+		}
+		if spec.GlobalPackage == "" {
+			spec.GlobalPackage = cfg.GlobalPackage
+		}
+		specs[i] = spec
+	}
+	return specs
+}
+
+// validateSpecs checks that specs are named uniquely and write to distinct directories
+func (cfg Config) validateSpecs() error {
+	names := make(map[string]bool)
+	outputs := make(map[string]string)
+	for _, spec := range cfg.specs() {
+		if len(cfg.Specs) > 0 {
+			if spec.Name == "" {
+				return errors.New("spec name cannot be empty")
+			}
+			if names[spec.Name] {
+				return fmt.Errorf("duplicate spec name: %s", spec.Name)
+			}
+			names[spec.Name] = true
+		}
+
+		output := filepath.Clean(spec.Output)
+		if other, ok := outputs[output]; ok {
+			return fmt.Errorf("specs %s and %s share the output directory %s", other, spec.Name, output)
+		}
+		outputs[output] = spec.Name
+	}
+	return nil
+}
+
+// specOptions converts the config and a spec into parser options
+func (cfg Config) specOptions(spec Spec) parser.Options {
+	opts := cfg.parserOptions()
+	opts.GlobalPackage = spec.GlobalPackage
+	opts.Packages = spec.Packages
+	opts.IncludeTags = spec.IncludeTags
+	opts.ExcludeTags = spec.ExcludeTags
+	return opts
+}
+
+// specResult a parsed spec
+type specResult struct {
+	spec    Spec
+	openapi *model.T
+}
+
+// parseSpecs loads the packages once and parses every spec from them.
+// A problem in code shared by several specs is reported once, prefixed with the names
+// of the specs it affects (e.g. "[public, admin] ...") when Specs is used
+func (cfg Config) parseSpecs() ([]specResult, parser.Diagnostics, error) {
+	prog, err := parser.Load(cfg.Dirs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse failed: %w", err)
+	}
+
+	var (
+		results []specResult
+		diags   parser.Diagnostics
+		names   [][]string
+		index   = make(map[parser.Diagnostic]int)
+	)
+	for _, spec := range cfg.specs() {
+		openapi, specDiags := prog.Parse(cfg.specOptions(spec))
+		results = append(results, specResult{spec: spec, openapi: openapi})
+
+		for _, d := range specDiags {
+			i, ok := index[d]
+			if !ok {
+				i = len(diags)
+				index[d] = i
+				diags = append(diags, d)
+				names = append(names, nil)
+			}
+			if spec.Name != "" {
+				names[i] = append(names[i], spec.Name)
+			}
+		}
+	}
+
+	for i := range diags {
+		if len(names[i]) > 0 {
+			diags[i].Message = fmt.Sprintf("[%s] %s", strings.Join(names[i], ", "), diags[i].Message)
+		}
+	}
+	return results, diags, nil
+}
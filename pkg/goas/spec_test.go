@@ -0,0 +1,132 @@
+package goas
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateSpecs(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{"single spec", Config{Output: "api"}, ""},
+		{"named specs", Config{Output: "api", Specs: []Spec{{Name: "public"}, {Name: "admin"}}}, ""},
+		{"empty name", Config{Specs: []Spec{{Name: ""}}}, "spec name cannot be empty"},
+		{"duplicate name", Config{Specs: []Spec{{Name: "public"}, {Name: "public"}}}, "duplicate spec name"},
+		{"shared output", Config{Specs: []Spec{{Name: "a", Output: "api"}, {Name: "b", Output: "./api/"}}}, "share the output directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validateSpecs()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateSpecs() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateSpecs() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpecsDefaults(t *testing.T) {
+	cfg := Config{Output: "api", GlobalPackage: "example.com/app", Specs: []Spec{
+		{Name: "public"},
+		{Name: "admin", Output: "admin-api", GlobalPackage: "example.com/app/admin"},
+	}}
+
+	specs := cfg.specs()
+	if got, want := specs[0].Output, filepath.Join("api", "public"); got != want {
+		t.Errorf("public output = %q, want %q", got, want)
+	}
+	if got := specs[0].GlobalPackage; got != "example.com/app" {
+		t.Errorf("public global package = %q, want the config default", got)
+	}
+	if got := specs[1].Output; got != "admin-api" {
+		t.Errorf("admin output = %q, want admin-api", got)
+	}
+	if got := specs[1].GlobalPackage; got != "example.com/app/admin" {
+		t.Errorf("admin global package = %q, want its own", got)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"valid", `{"dirs": ["./..."], "specs": [{"name": "public", "excludeTags": ["internal"]}]}`, false},
+		{"unknown field", `{"dirs": ["./..."], "outptu": "api"}`, true},
+		{"invalid json", `{"dirs": `, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "goas.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadConfig(path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSpecsDedupe(t *testing.T) {
+	// go/packages resolves patterns against the working directory
+	t.Chdir(filepath.Join("..", "parser", "testdata", "app"))
+
+	cfg := Config{
+		Dirs:          []string{"./..."},
+		GlobalPackage: "example.com/app",
+		Specs: []Spec{
+			{Name: "public", ExcludeTags: []string{"internal"}},
+			{Name: "users", Packages: []string{"example.com/app/filter", "example.com/app/methods"}},
+		},
+	}
+	results, diags, err := cfg.parseSpecs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d specs, want 2", len(results))
+	}
+
+	counts := make(map[string]int)
+	for _, d := range diags {
+		counts[d.Pos.String()+" "+d.Message]++
+	}
+	for key, n := range counts {
+		if n > 1 {
+			t.Errorf("diagnostic reported %d times: %s", n, key)
+		}
+	}
+
+	var shared, own bool
+	for _, d := range diags {
+		switch {
+		case strings.Contains(d.Message, "[gettt]"):
+			// The typo lives in a package both specs include
+			shared = strings.HasPrefix(d.Message, "[public, users] ")
+		case strings.Contains(d.Message, "OperationID"):
+			// Duplicate operationIds only exist in packages outside the users spec
+			own = own || strings.HasPrefix(d.Message, "[public] ")
+		}
+	}
+	if !shared {
+		t.Errorf("missing diagnostic shared by both specs: %v", diags)
+	}
+	if !own {
+		t.Errorf("missing diagnostic of the public spec only: %v", diags)
+	}
+}
@@ -0,0 +1,139 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// OrderedMap 按插入顺序保存键值对的映射
+// 用于 Paths、Schema.Properties 等需要稳定输出顺序的场景，序列化时保持插入顺序
+type OrderedMap[V any] struct {
+	keys   []string
+	values map[string]V
+}
+
+// NewOrderedMap 创建一个空的有序映射
+func NewOrderedMap[V any]() *OrderedMap[V] {
+	return &OrderedMap[V]{values: make(map[string]V)}
+}
+
+// Get 获取键对应的值
+func (m *OrderedMap[V]) Get(key string) (V, bool) {
+	if m == nil {
+		var zero V
+		return zero, false
+	}
+	v, ok := m.values[key]
+	return v, ok
+}
+
+// Set 设置键值，已存在的键保持原有位置
+func (m *OrderedMap[V]) Set(key string, value V) {
+	if m.values == nil {
+		m.values = make(map[string]V)
+	}
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = value
+}
+
+// Delete 删除键
+func (m *OrderedMap[V]) Delete(key string) {
+	if m == nil {
+		return
+	}
+	if _, ok := m.values[key]; !ok {
+		return
+	}
+	delete(m.values, key)
+	for i, k := range m.keys {
+		if k == key {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// Keys 按顺序返回所有键
+func (m *OrderedMap[V]) Keys() []string {
+	if m == nil {
+		return nil
+	}
+	return append([]string(nil), m.keys...)
+}
+
+// Len 返回键值对数量
+func (m *OrderedMap[V]) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.keys)
+}
+
+// Sort 按给定规则重新排列键的顺序
+func (m *OrderedMap[V]) Sort(less func(a, b string) bool) {
+	if m == nil {
+		return
+	}
+	sort.SliceStable(m.keys, func(i, j int) bool {
+		return less(m.keys[i], m.keys[j])
+	})
+}
+
+func (m OrderedMap[V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		val, err := json.Marshal(m.values[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+func (m *OrderedMap[V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("OrderedMap: 期望 JSON 对象, 实际为 %v", tok)
+	}
+
+	m.keys = nil
+	m.values = make(map[string]V)
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := keyTok.(string)
+
+		var v V
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		m.Set(key, v)
+	}
+
+	_, err = dec.Token()
+	return err
+}
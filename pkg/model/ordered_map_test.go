@@ -0,0 +1,83 @@
+package model
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestOrderedMap(t *testing.T) {
+	tests := []struct {
+		name string
+		ops  func(m *OrderedMap[int])
+		want string
+	}{
+		{
+			name: "保持插入顺序",
+			ops: func(m *OrderedMap[int]) {
+				m.Set("b", 1)
+				m.Set("a", 2)
+				m.Set("c", 3)
+			},
+			want: `{"b":1,"a":2,"c":3}`,
+		},
+		{
+			name: "覆盖已有键保持原有位置",
+			ops: func(m *OrderedMap[int]) {
+				m.Set("b", 1)
+				m.Set("a", 2)
+				m.Set("b", 3)
+			},
+			want: `{"b":3,"a":2}`,
+		},
+		{
+			name: "删除",
+			ops: func(m *OrderedMap[int]) {
+				m.Set("b", 1)
+				m.Set("a", 2)
+				m.Set("c", 3)
+				m.Delete("a")
+				m.Delete("missing")
+			},
+			want: `{"b":1,"c":3}`,
+		},
+		{
+			name: "排序",
+			ops: func(m *OrderedMap[int]) {
+				m.Set("/users/{id}", 1)
+				m.Set("/orders", 2)
+				m.Set("/users", 3)
+				m.Sort(func(a, b string) bool { return a < b })
+			},
+			want: `{"/orders":2,"/users":3,"/users/{id}":1}`,
+		},
+		{
+			name: "空映射",
+			ops:  func(m *OrderedMap[int]) {},
+			want: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewOrderedMap[int]()
+			tt.ops(m)
+			got, err := json.Marshal(m)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+
+			// 反序列化后顺序不变
+			var decoded OrderedMap[int]
+			if err := json.Unmarshal(got, &decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !slices.Equal(decoded.Keys(), m.Keys()) {
+				t.Errorf("Unmarshal() keys = %v, want %v", decoded.Keys(), m.Keys())
+			}
+		})
+	}
+}
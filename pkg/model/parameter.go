@@ -18,8 +18,9 @@ type Parameter struct {
 	AllowEmptyValue bool `json:"allowEmptyValue,omitempty"`
 
 	// 序列化相关
-	Style         string                `json:"style,omitempty"`
-	Explode       bool                  `json:"explode,omitempty"`
+	Style string `json:"style,omitempty"`
+	// 为 nil 时使用 style 的默认值 (form 为 true，其他为 false)
+	Explode       *bool                 `json:"explode,omitempty"`
 	AllowReserved bool                  `json:"allowReserved,omitempty"`
 	Schema        *Schema               `json:"schema,omitempty"`
 	Example       any                   `json:"example,omitempty"`
@@ -4,11 +4,14 @@ import "encoding/json"
 
 // Paths 持有各个路径及其操作的定义
 type Paths struct {
-	// 接口的路径映射
-	Paths map[string]*PathItem `json:"-"` // JSON 无 inline tag，用 MarshalJSON/UnmarshalJSON 扁平化输出
+	// 接口的路径映射，按注册顺序输出
+	Paths *OrderedMap[*PathItem] `json:"-"` // JSON 无 inline tag，用 MarshalJSON/UnmarshalJSON 扁平化输出
 }
 
 func (p Paths) MarshalJSON() ([]byte, error) {
+	if p.Paths == nil {
+		return []byte("{}"), nil
+	}
 	return json.Marshal(p.Paths)
 }
 
@@ -16,7 +19,8 @@ func (p *Paths) UnmarshalJSON(data []byte) error {
 	if p == nil {
 		return nil
 	}
-	return json.Unmarshal(data, &p.Paths)
+	p.Paths = NewOrderedMap[*PathItem]()
+	return json.Unmarshal(data, p.Paths)
 }
 
 // PathItem 描述在单个路径上可用的操作
@@ -1,6 +1,10 @@
 package model
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+)
 
 // Responses 操作预期响应的容器
 type Responses struct {
@@ -10,17 +14,36 @@ type Responses struct {
 	Codes map[string]*Response `json:"-"`
 }
 
+// MarshalJSON 按状态码升序输出，default 放在最后
 func (r Responses) MarshalJSON() ([]byte, error) {
-	out := map[string]*Response{}
-	if r.Default != nil {
-		out["default"] = r.Default
+	codes := make([]string, 0, len(r.Codes))
+	for k := range r.Codes {
+		codes = append(codes, k)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return lessStatusCode(codes[i], codes[j])
+	})
+
+	out := NewOrderedMap[*Response]()
+	for _, k := range codes {
+		out.Set(k, r.Codes[k])
 	}
-	for k, v := range r.Codes {
-		out[k] = v
+	if r.Default != nil {
+		out.Set("default", r.Default)
 	}
 	return json.Marshal(out)
 }
 
+// lessStatusCode 比较两个状态码
+// 通配状态码 (如 2XX) 排在同一区间的具体状态码之后
+func lessStatusCode(a, b string) bool {
+	a, b = strings.ToUpper(a), strings.ToUpper(b)
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
 func (r *Responses) UnmarshalJSON(data []byte) error {
 	if r == nil {
 		return nil
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestResponsesOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		codes []string
+		dflt  bool
+		want  []string
+	}{
+		{
+			name:  "状态码升序",
+			codes: []string{"404", "200", "201", "500"},
+			want:  []string{"200", "201", "404", "500"},
+		},
+		{
+			name:  "通配状态码排在同一区间的具体状态码之后",
+			codes: []string{"4XX", "2XX", "400", "200"},
+			want:  []string{"200", "2XX", "400", "4XX"},
+		},
+		{
+			name:  "default 放在最后",
+			codes: []string{"200"},
+			dflt:  true,
+			want:  []string{"200", "default"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Responses{Codes: make(map[string]*Response)}
+			for _, code := range tt.codes {
+				r.Codes[code] = &Response{}
+			}
+			if tt.dflt {
+				r.Default = &Response{}
+			}
+			data, err := json.Marshal(r)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var got OrderedMap[json.RawMessage]
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !slices.Equal(got.Keys(), tt.want) {
+				t.Errorf("Marshal() keys = %v, want %v", got.Keys(), tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,12 @@
 package model
 
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
 // Schema 允许定义输入和输出数据类型
 type Schema struct {
 	// JSON Schema 核心字段
@@ -26,13 +33,13 @@ type Schema struct {
 	Contains    *Schema   `json:"contains,omitempty"`
 
 	// 对象相关
-	Properties           map[string]*Schema `json:"properties,omitempty"`
-	PatternProperties    map[string]*Schema `json:"patternProperties,omitempty"`
-	AdditionalProperties any                `json:"additionalProperties,omitempty"` // bool 或 *Schema
-	Required             []string           `json:"required,omitempty"`
-	MaxProperties        int                `json:"maxProperties,omitempty"`
-	MinProperties        int                `json:"minProperties,omitempty"`
-	PropertyNames        *Schema            `json:"propertyNames,omitempty"`
+	Properties           *OrderedMap[*Schema] `json:"properties,omitempty"` // 按结构体字段声明顺序输出
+	PatternProperties    map[string]*Schema   `json:"patternProperties,omitempty"`
+	AdditionalProperties any                  `json:"additionalProperties,omitempty"` // bool 或 *Schema
+	Required             []string             `json:"required,omitempty"`
+	MaxProperties        int                  `json:"maxProperties,omitempty"`
+	MinProperties        int                  `json:"minProperties,omitempty"`
+	PropertyNames        *Schema              `json:"propertyNames,omitempty"`
 
 	// 组合模式
 	AllOf []*Schema `json:"allOf,omitempty"`
@@ -70,6 +77,89 @@ type Schema struct {
 	ExternalDocs  *ExternalDocs  `json:"externalDocs,omitempty"`
 	Example       any            `json:"example,omitempty"` // 已弃用，使用 examples
 	Deprecated    bool           `json:"deprecated,omitempty"`
+
+	// 规范扩展字段 (键以 x- 开头)，序列化时与其他字段平铺输出
+	Extensions map[string]any `json:"-"`
+}
+
+// MarshalJSON 在常规字段之后按键名顺序追加扩展字段
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type schemaAlias Schema
+	data, err := json.Marshal(schemaAlias(s))
+	if err != nil || len(s.Extensions) == 0 {
+		return data, err
+	}
+
+	keys := make([]string, 0, len(s.Extensions))
+	for k := range s.Extensions {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for i, k := range keys {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(s.Extensions[k])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON 解析常规字段，并将 x- 开头的键收集到 Extensions
+// 类型数组 ("type": ["string", "null"]) 解析为 []string，与生成时使用的 Go 类型一致
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type schemaAlias Schema
+	if err := json.Unmarshal(data, (*schemaAlias)(s)); err != nil {
+		return err
+	}
+	if types, ok := s.Type.([]any); ok {
+		s.Type = typeNames(types)
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for k, v := range raw {
+		if !strings.HasPrefix(k, "x-") {
+			continue
+		}
+		var ext any
+		if err := json.Unmarshal(v, &ext); err != nil {
+			return err
+		}
+		if s.Extensions == nil {
+			s.Extensions = make(map[string]any)
+		}
+		s.Extensions[k] = ext
+	}
+	return nil
+}
+
+// typeNames 将 JSON 解析得到的类型数组转换为 []string，包含非字符串元素时保持原样
+func typeNames(types []any) any {
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		name, ok := t.(string)
+		if !ok {
+			return types
+		}
+		names = append(names, name)
+	}
+	return names
 }
 
 // Discriminator 帮助多态
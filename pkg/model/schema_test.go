@@ -0,0 +1,48 @@
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSchemaUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want *Schema
+	}{
+		{
+			name: "单个类型",
+			json: `{"type":"string","format":"uuid"}`,
+			want: &Schema{Type: "string", Format: "uuid"},
+		},
+		{
+			name: "类型数组解析为 []string",
+			json: `{"type":["string","null"]}`,
+			want: &Schema{Type: []string{"string", "null"}},
+		},
+		{
+			name: "嵌套 Schema 的类型数组",
+			json: `{"type":"array","items":{"type":["integer","null"]}}`,
+			want: &Schema{Type: "array", Items: &Schema{Type: []string{"integer", "null"}}},
+		},
+		{
+			name: "扩展字段",
+			json: `{"type":"string","x-enum-varnames":["A"]}`,
+			want: &Schema{Type: "string", Extensions: map[string]any{"x-enum-varnames": []any{"A"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &Schema{}
+			if err := json.Unmarshal([]byte(tt.json), got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Unmarshal() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"go/ast"
 	"regexp"
 	"strings"
 )
@@ -45,6 +46,9 @@ const (
 	TagTagDocsURL  = "@tag.docs.url"
 	TagTagDocsDesc = "@tag.docs.desc"
 
+	// 全局注解来源标记，用于 main 函数以外的函数或变量声明
+	TagGoasGlobal = "@goasglobal"
+
 	// 安全方案
 	TagSecurityScheme = "@securityscheme"
 	TagSecurityScope  = "@securityscope"
@@ -115,6 +119,70 @@ func parseCommentLine(line string) (tag string, content string) {
 	return tag, content
 }
 
+// hasAnnotation 检查注释中是否有指定的注解 (tag 为小写，如 @router)
+func hasAnnotation(doc *ast.CommentGroup, tag string) bool {
+	if doc == nil {
+		return false
+	}
+	for _, comment := range doc.List {
+		if t, _ := parseCommentLine(comment.Text); t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// globalOnlyTags 只在全局注释中使用的注解，@Summary、@Description 等与接口注解同名的不计入
+var globalOnlyTags = map[string]bool{
+	TagOpenAPI: true, TagSelf: true, TagJsonSchemaDialect: true,
+	TagTitle: true, TagVersion: true, TagTermsOfService: true,
+	TagContactName: true, TagContactURL: true, TagContactEmail: true,
+	TagLicenseName: true, TagLicenseIdentifier: true, TagLicenseURL: true,
+	TagTagName: true, TagTagSummary: true, TagTagDesc: true, TagTagParent: true,
+	TagTagKind: true, TagTagDocsURL: true, TagTagDocsDesc: true,
+	TagServer: true, TagSecurityScheme: true, TagSecurityScope: true,
+}
+
+// hasGlobalAnnotation 检查注释中是否有只在全局注释中使用的注解 (如 @Title、@Version、@Server)
+func hasGlobalAnnotation(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, comment := range doc.List {
+		if t, _ := parseCommentLine(comment.Text); globalOnlyTags[t] {
+			return true
+		}
+	}
+	return false
+}
+
+// hasAnnotations 检查注释中是否有任意注解
+func hasAnnotations(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+	for _, comment := range doc.List {
+		if t, _ := parseCommentLine(comment.Text); t != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// genDeclDocs 获取声明的注释: var x = ... 的注释在 GenDecl 上，var ( x = ... ) 的注释在各个 Spec 上
+func genDeclDocs(decl *ast.GenDecl) []*ast.CommentGroup {
+	docs := []*ast.CommentGroup{decl.Doc}
+	for _, spec := range decl.Specs {
+		switch s := spec.(type) {
+		case *ast.ValueSpec:
+			docs = append(docs, s.Doc)
+		case *ast.TypeSpec:
+			docs = append(docs, s.Doc)
+		}
+	}
+	return docs
+}
+
 // splitParams 按空格分割参数，但保留引号内的内容
 // 输入: `id path int true "用户ID"`
 // 输出: ["id", "path", "int", "true", "用户ID"]
@@ -149,6 +217,56 @@ func splitParams(s string) []string {
 	return result
 }
 
+// paramAttribute @Param 描述之后的属性
+type paramAttribute struct {
+	// 属性名 (小写)
+	name  string
+	value string
+}
+
+// paramAttributeNames @Param 支持的属性
+var paramAttributeNames = map[string]bool{
+	"enums": true, "enum": true, "default": true, "example": true,
+	"minimum": true, "maximum": true, "minlength": true, "maxlength": true,
+	"pattern": true, "format": true,
+	"deprecated": true, "style": true, "explode": true, "allowemptyvalue": true,
+}
+
+// splitParamAttributes 拆分 @Param 的描述与末尾的属性
+// 属性的形式为 key(value) 或 key=value，只识别末尾连续的已知属性，其余部分作为描述
+// 输入: ["排序字段", "enums(asc,desc)", "default(asc)"]
+// 输出: ("排序字段", [{enums asc,desc} {default asc}])
+func splitParamAttributes(parts []string) (desc string, attrs []paramAttribute) {
+	end := len(parts)
+	for end > 0 {
+		attr, ok := parseParamAttribute(parts[end-1])
+		if !ok {
+			break
+		}
+		attrs = append([]paramAttribute{attr}, attrs...)
+		end--
+	}
+	return strings.Join(parts[:end], " "), attrs
+}
+
+// parseParamAttribute 解析单个属性: enums(asc,desc) 或 default=asc
+func parseParamAttribute(s string) (paramAttribute, bool) {
+	var name, value string
+	if open := strings.Index(s, "("); open > 0 && strings.HasSuffix(s, ")") {
+		name, value = s[:open], s[open+1:len(s)-1]
+	} else if k, v, ok := strings.Cut(s, "="); ok {
+		name, value = k, v
+	} else {
+		return paramAttribute{}, false
+	}
+
+	name = strings.ToLower(name)
+	if !paramAttributeNames[name] {
+		return paramAttribute{}, false
+	}
+	return paramAttribute{name: name, value: value}, true
+}
+
 // parseMimeTypes 解析逗号分隔的 MIME 类型
 // 输入: "json,xml"
 // 输出: ["application/json", "application/xml"]
@@ -175,8 +293,8 @@ func parseMimeTypes(s string) []string {
 // 输入: "/users/{id} [get]"
 // 输出: ("/users/{id}", "get")
 func parseRouterPath(s string) (path string, method string) {
-	// 使用正则匹配 [method]
-	re := regexp.MustCompile(`\[(\w+)\]`)
+	// 使用正则匹配 [method]，方法名是否符合 HTTP token 语法在添加接口时校验
+	re := regexp.MustCompile(`\[([^\[\]\s]+)\]`)
 	matches := re.FindStringSubmatch(s)
 
 	if len(matches) > 1 {
@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// checkReferences 校验接口中引用的名称是否已在全局注释中声明
+// - @Security 引用的方案必须通过 @SecurityScheme 声明
+// - @Tags 引用的标签必须通过 @Tag.Name 声明
+// - additionalOperations 需要 OpenAPI 3.2 及以上版本
+func (p *Processor) checkReferences() {
+	schemes := make(map[string]bool)
+	if p.OpenAPI.Components != nil {
+		for name := range p.OpenAPI.Components.SecuritySchemes {
+			schemes[name] = true
+		}
+	}
+	for _, ref := range p.securityRefs {
+		if !schemes[ref.name] {
+			p.report(ref.pos, SeverityWarning, fmt.Sprintf("@Security 引用了未声明的安全方案 %q (需使用 @SecurityScheme 声明)", ref.name))
+		}
+	}
+
+	tags := make(map[string]bool)
+	for _, tag := range p.OpenAPI.Tags {
+		tags[tag.Name] = true
+	}
+	for _, ref := range p.tagRefs {
+		if !tags[ref.name] {
+			p.report(ref.pos, SeverityWarning, fmt.Sprintf("@Tags 引用了未声明的标签 %q (需使用 @Tag.Name 声明)", ref.name))
+		}
+	}
+
+	// additionalOperations 是 OpenAPI 3.2 新增的字段，更早的版本中文档无效
+	if version := p.OpenAPI.OpenAPI; versionBefore(version, 3, 2) {
+		for _, ref := range p.additionalOps {
+			p.report(ref.pos, SeverityWarning, fmt.Sprintf("方法 %s 输出到 additionalOperations，需要 OpenAPI 3.2 及以上版本，当前为 %s", ref.name, version))
+		}
+	}
+}
+
+// versionBefore 检查 OpenAPI 版本号 (如 "3.1.0") 是否早于 major.minor，无法解析的版本号返回 false
+func versionBefore(version string, major, minor int) bool {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	vMajor, err1 := strconv.Atoi(parts[0])
+	vMinor, err2 := strconv.Atoi(parts[1])
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	return vMajor < major || (vMajor == major && vMinor < minor)
+}
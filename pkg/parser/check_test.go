@@ -0,0 +1,26 @@
+package parser
+
+import "testing"
+
+func TestVersionBefore(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{"3.0.3", true},
+		{"3.1.0", true},
+		{"3.2.0", false},
+		{"3.10.0", false},
+		{"4.0", false},
+		{"2.0", true},
+		{"3", false},
+		{"", false},
+		{"v3.1", false},
+	}
+
+	for _, tt := range tests {
+		if got := versionBefore(tt.version, 3, 2); got != tt.want {
+			t.Errorf("versionBefore(%q, 3, 2) = %v, want %v", tt.version, got, tt.want)
+		}
+	}
+}
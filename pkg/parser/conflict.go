@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"strings"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+// OperationID 冲突的处理方式
+const (
+	// OperationIDReport 只报告重复的 OperationID (默认)
+	OperationIDReport = "report"
+	// OperationIDPackage 为重复的 OperationID 加上包名前缀: user_List、order_List
+	OperationIDPackage = "package"
+	// OperationIDTag 为重复的 OperationID 加上第一个标签作为前缀 (没有标签时使用包名): Users_List
+	OperationIDTag = "tag"
+)
+
+// 路径模板中的参数: /users/{id} 与 /users/{uid} 是同一个路径
+var pathParamPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// operationOwner 占用某个 OperationID 的接口
+type operationOwner struct {
+	op  *model.Operation
+	pos token.Pos
+	// 冲突时使用的前缀，为空表示不自动消歧
+	prefix string
+	// OperationID 来自 @Id 注解，不自动修改
+	explicit bool
+	// 已因冲突加上前缀
+	renamed bool
+}
+
+// routeKey 路由的唯一标识，路径参数名不同的路径视为同一个路径
+func routeKey(method, path string) string {
+	return strings.ToUpper(method) + " " + pathParamPattern.ReplaceAllString(path, "{}")
+}
+
+// claimRoute 检查路由是否已被其他接口定义，重复时报告两处位置并返回 false
+func (p *Processor) claimRoute(method, path string, pos token.Pos) bool {
+	key := routeKey(method, path)
+	if owner, taken := p.routeOwners[key]; taken {
+		p.report(pos, SeverityWarning, fmt.Sprintf("接口 [%s] %s 已在 %s 定义，重复的定义已忽略",
+			method, path, p.fset.Position(owner)))
+		return false
+	}
+	p.routeOwners[key] = pos
+	return true
+}
+
+// claimOperationID 登记接口的 OperationID
+// 与已有的 OperationID 重复时，按 Options.OperationIDConflict 为双方加上前缀，无法消歧时报告两处位置
+func (p *Processor) claimOperationID(op *model.Operation, pos token.Pos, prefix string, explicit bool) {
+	id := op.OperationID
+	existing, taken := p.operationIDs[id]
+	if !taken {
+		p.operationIDs[id] = &operationOwner{op: op, pos: pos, prefix: prefix, explicit: explicit}
+		return
+	}
+
+	// 先前同名的接口都已加上前缀，@Id 声明的 OperationID 可以直接使用
+	if existing.renamed && explicit {
+		p.operationIDs[id] = &operationOwner{op: op, pos: pos, explicit: true}
+		return
+	}
+
+	if prefix != "" && !explicit {
+		// 先前的接口也加上前缀，使同名接口的命名保持一致；原 OperationID 继续保留为占用状态
+		if !existing.explicit && !existing.renamed && existing.prefix != "" {
+			if renamed := existing.prefix + "_" + id; p.operationIDs[renamed] == nil {
+				existing.op.OperationID = renamed
+				existing.renamed = true
+				// 加上前缀后的 OperationID 不再修改
+				p.operationIDs[renamed] = &operationOwner{op: existing.op, pos: existing.pos, explicit: true}
+			}
+		}
+
+		if renamed := prefix + "_" + id; p.operationIDs[renamed] == nil {
+			op.OperationID = renamed
+			p.operationIDs[renamed] = &operationOwner{op: op, pos: pos, explicit: true}
+			return
+		}
+	}
+
+	p.report(pos, SeverityWarning, fmt.Sprintf("OperationID %q 与 %s 的接口重复", op.OperationID, p.fset.Position(existing.pos)))
+}
+
+// operationIDPrefix 按 Options.OperationIDConflict 生成消歧前缀
+func (p *Processor) operationIDPrefix(pkgName string, op *model.Operation) string {
+	switch p.opts.OperationIDConflict {
+	case OperationIDPackage:
+		return pkgName
+	case OperationIDTag:
+		if len(op.Tags) > 0 {
+			return sanitizeSchemaName(op.Tags[0])
+		}
+		return pkgName
+	default:
+		return ""
+	}
+}
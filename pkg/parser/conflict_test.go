@@ -0,0 +1,74 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRouteKey(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"get /users/{id}", "GET /users/{uid}", true},
+		{"put /users/{id}/orders/{oid}", "PUT /users/{uid}/orders/{id}", true},
+		{"get /users/{id}", "post /users/{id}", false},
+		{"get /users/{id}", "get /users/me", false},
+		{"get /users", "get /users/", false},
+	}
+
+	for _, tt := range tests {
+		methodA, pathA, _ := strings.Cut(tt.a, " ")
+		methodB, pathB, _ := strings.Cut(tt.b, " ")
+		if got := routeKey(methodA, pathA) == routeKey(methodB, pathB); got != tt.same {
+			t.Errorf("routeKey(%q) == routeKey(%q) = %v, want %v", tt.a, tt.b, got, tt.same)
+		}
+	}
+}
+
+func TestDuplicateRoute(t *testing.T) {
+	openapi, diags := parseApp(t, Options{})
+
+	// 包按路径顺序扫描，conflict/order 先于 conflict/user
+	if op := findOperation(openapi, "/conflict/users/{id}", "put"); op == nil || op.Summary != "update user again" {
+		t.Errorf("应保留先定义的接口 [put] /conflict/users/{id}，got %+v", op)
+	}
+	if op := findOperation(openapi, "/conflict/users/{uid}", "put"); op != nil {
+		t.Errorf("仅参数名不同的重复接口 [put] /conflict/users/{uid} 应被忽略")
+	}
+	if !hasDiagnostic(diags, "接口 [put] /conflict/users/{uid} 已在") {
+		t.Errorf("缺少重复接口的警告: %v", diags)
+	}
+}
+
+func TestOperationIDConflict(t *testing.T) {
+	tests := []struct {
+		strategy  string
+		wantUsers string
+		wantOrder string
+		wantWarn  bool
+	}{
+		{OperationIDReport, "List", "List", true},
+		{"", "List", "List", true},
+		{OperationIDPackage, "user_List", "order_List", false},
+		{OperationIDTag, "users_List", "orders_List", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			openapi, diags := parseApp(t, Options{OperationIDConflict: tt.strategy})
+
+			users := findOperation(openapi, "/conflict/users", "get")
+			orders := findOperation(openapi, "/conflict/orders", "get")
+			if users == nil || orders == nil {
+				t.Fatal("未找到 /conflict/users 或 /conflict/orders")
+			}
+			if users.OperationID != tt.wantUsers || orders.OperationID != tt.wantOrder {
+				t.Errorf("OperationID = %q, %q, want %q, %q", users.OperationID, orders.OperationID, tt.wantUsers, tt.wantOrder)
+			}
+			if got := hasDiagnostic(diags, `OperationID "List" 与`); got != tt.wantWarn {
+				t.Errorf("OperationID 重复警告 = %v, want %v", got, tt.wantWarn)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"fmt"
+	"go/token"
+)
+
+// Severity 诊断级别
+type Severity int
+
+const (
+	// SeverityWarning 注释存在问题，但仍可生成文档 (可能与预期不符)
+	SeverityWarning Severity = iota
+	// SeverityError 注释无法解析，相关内容被丢弃或退化
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	default:
+		return "warning"
+	}
+}
+
+// Diagnostic 解析过程中发现的问题
+type Diagnostic struct {
+	// 问题所在的源码位置 (注释行)
+	Pos token.Position
+	// 诊断级别
+	Severity Severity
+	// 问题描述
+	Message string
+}
+
+// String 输出形如 "handler.go:12:1: warning: xxx" 的格式，便于编辑器跳转
+func (d Diagnostic) String() string {
+	if d.Pos.IsValid() {
+		return fmt.Sprintf("%s: %s: %s", d.Pos, d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", d.Severity, d.Message)
+}
+
+// Diagnostics 诊断列表
+type Diagnostics []Diagnostic
+
+// HasErrors 是否包含 error 级别的诊断
+func (ds Diagnostics) HasErrors() bool {
+	for _, d := range ds {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// warnf 在当前注释位置记录一条警告
+func (p *Processor) warnf(format string, args ...any) {
+	p.report(p.pos, SeverityWarning, fmt.Sprintf(format, args...))
+}
+
+// errorf 在当前注释位置记录一条错误
+func (p *Processor) errorf(format string, args ...any) {
+	p.report(p.pos, SeverityError, fmt.Sprintf(format, args...))
+}
+
+// report 记录一条诊断
+func (p *Processor) report(pos token.Pos, severity Severity, msg string) {
+	d := Diagnostic{Severity: severity, Message: msg}
+	if p.fset != nil && pos.IsValid() {
+		d.Pos = p.fset.Position(pos)
+	}
+	p.Diagnostics = append(p.Diagnostics, d)
+}
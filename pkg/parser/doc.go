@@ -0,0 +1,147 @@
+package parser
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// indexDocs 记录包内类型、结构体字段、常量的文档注释
+// Key 为声明名称标识符的位置，与 go/types 中对象的 Pos() 一致，
+// 这样在只拿到 types.Var / types.TypeName 时也能找回对应的注释
+func (p *Processor) indexDocs(pkg *packages.Package) {
+	if isStandardLibrary(pkg.PkgPath) {
+		return
+	}
+
+	for _, file := range pkg.Syntax {
+		ast.Inspect(file, func(n ast.Node) bool {
+			decl, ok := n.(*ast.GenDecl)
+			if !ok {
+				return true
+			}
+
+			for _, spec := range decl.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					doc := s.Doc
+					// 非分组声明 (type X struct{}) 的注释挂在 GenDecl 上
+					if doc == nil && !decl.Lparen.IsValid() {
+						doc = decl.Doc
+					}
+					p.addDoc(s.Name.Pos(), doc, s.Comment)
+					p.addSchemaAnnotation(pkg, s.Name.Pos(), doc)
+
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						p.addDoc(name.Pos(), s.Doc, s.Comment)
+					}
+				}
+			}
+			return true
+		})
+
+		// 结构体字段 (包括匿名结构体中的字段)
+		ast.Inspect(file, func(n ast.Node) bool {
+			st, ok := n.(*ast.StructType)
+			if !ok || st.Fields == nil {
+				return true
+			}
+
+			for _, field := range st.Fields.List {
+				if len(field.Names) == 0 {
+					// 嵌入字段: types.Var 的位置是类型名标识符的位置
+					if ident := embeddedIdent(field.Type); ident != nil {
+						p.addDoc(ident.Pos(), field.Doc, field.Comment)
+					}
+					continue
+				}
+				for _, name := range field.Names {
+					p.addDoc(name.Pos(), field.Doc, field.Comment)
+				}
+			}
+			return true
+		})
+	}
+}
+
+// addDoc 保存注释文本，优先使用上方的文档注释，其次使用行尾注释
+func (p *Processor) addDoc(pos token.Pos, doc, comment *ast.CommentGroup) {
+	text := commentText(doc)
+	if text == "" {
+		text = commentText(comment)
+	}
+	if text != "" {
+		p.docs[pos] = text
+	}
+}
+
+// addSchemaAnnotation 记录类型文档注释中的 @Schema 注解
+func (p *Processor) addSchemaAnnotation(pkg *packages.Package, pos token.Pos, doc *ast.CommentGroup) {
+	if doc == nil {
+		return
+	}
+	for _, c := range doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+		if value, ok := strings.CutPrefix(line, "@Schema"); ok && (value == "" || value[0] == ' ' || value[0] == '\t') {
+			p.schemaAnnotations[pos] = schemaAnnotation{
+				pkg:   pkg,
+				value: strings.TrimSpace(value),
+				pos:   c.Pos(),
+			}
+			return
+		}
+	}
+}
+
+// docOf 获取声明位置对应的注释
+func (p *Processor) docOf(pos token.Pos) string {
+	return p.docs[pos]
+}
+
+// namedDoc 获取类型或字段的文档注释，并按 Go 注释惯例去掉开头的声明名称
+// 输入: ("User", "User 用户模型") -> "用户模型"
+func (p *Processor) namedDoc(pos token.Pos, name string) string {
+	doc := p.docOf(pos)
+	if rest, ok := strings.CutPrefix(doc, name+" "); ok {
+		doc = strings.TrimSpace(rest)
+	}
+	return doc
+}
+
+// commentText 提取注释文本，忽略 goas 注解行 (以 @ 开头)
+func commentText(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+
+	var lines []string
+	for _, line := range strings.Split(cg.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "@") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// embeddedIdent 获取嵌入字段类型表达式中的类型名标识符
+// 支持: T, *T, pkg.T, T[A], *pkg.T[A]
+func embeddedIdent(expr ast.Expr) *ast.Ident {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e
+	case *ast.StarExpr:
+		return embeddedIdent(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel
+	case *ast.IndexExpr:
+		return embeddedIdent(e.X)
+	case *ast.IndexListExpr:
+		return embeddedIdent(e.X)
+	}
+	return nil
+}
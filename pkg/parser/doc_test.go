@@ -0,0 +1,37 @@
+package parser
+
+import "testing"
+
+func TestDocDescriptions(t *testing.T) {
+	openapi, _ := parseApp(t, Options{})
+	profile := componentSchema(t, openapi, "Profile")
+
+	// 类型注释去掉开头的类型名
+	if profile.Description != "用户资料" {
+		t.Errorf("Profile 的描述 = %q, want %q", profile.Description, "用户资料")
+	}
+	// 注释不覆盖 Title，Title 仍是类型名
+	if profile.Title != "Profile" {
+		t.Errorf("Profile 的 Title = %q, want %q", profile.Title, "Profile")
+	}
+
+	tests := []struct {
+		field string
+		want  string
+	}{
+		{"nickname", "昵称"},
+		{"avatar", "头像地址"},
+		{"bio", "个人简介"},
+		{"age", ""},
+	}
+
+	for _, tt := range tests {
+		prop, ok := profile.Properties.Get(tt.field)
+		if !ok {
+			t.Fatalf("Profile 缺少属性 %s", tt.field)
+		}
+		if prop.Description != tt.want {
+			t.Errorf("%s 的描述 = %q, want %q", tt.field, prop.Description, tt.want)
+		}
+	}
+}
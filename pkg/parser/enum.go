@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"go/constant"
+	"go/types"
+	"sort"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+// 枚举相关的扩展字段，供客户端生成器 (openapi-generator 等) 生成具名枚举
+const (
+	extEnumVarNames     = "x-enum-varnames"
+	extEnumDescriptions = "x-enum-descriptions"
+)
+
+// enumSchema 为基本类型的命名类型生成枚举 Schema
+// 在定义该类型的包中查找所有该类型的常量，如:
+//
+//	type Status string
+//	const (
+//		StatusActive   Status = "active"   // 正常
+//		StatusDisabled Status = "disabled" // 已禁用
+//	)
+//
+// 生成 {type: string, enum: [active, disabled], x-enum-varnames: [...], x-enum-descriptions: [...]}
+// 只处理项目代码中的类型，标准库与第三方依赖 (如 http.SameSite、fs.FileMode) 的常量不是完整的取值列表
+// 没有找到常量时返回 nil
+func (p *Processor) enumSchema(named *types.Named) *model.Schema {
+	basic, ok := named.Underlying().(*types.Basic)
+	if !ok || named.Obj().Pkg() == nil || !p.isScannedPackage(named.Obj().Pkg().Path()) {
+		return nil
+	}
+
+	// 按源码声明顺序收集常量
+	scope := named.Obj().Pkg().Scope()
+	var consts []*types.Const
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if ok && types.Identical(c.Type(), named) {
+			consts = append(consts, c)
+		}
+	}
+	if len(consts) == 0 {
+		return nil
+	}
+	sort.Slice(consts, func(i, j int) bool {
+		return consts[i].Pos() < consts[j].Pos()
+	})
+
+	schema := p.basicTypeToSchema(basic)
+	var (
+		names   []string
+		descs   []string
+		hasDesc bool
+	)
+	for _, c := range consts {
+		schema.Enum = append(schema.Enum, constantValue(c.Val()))
+		names = append(names, c.Name())
+
+		desc := p.namedDoc(c.Pos(), c.Name())
+		if desc != "" {
+			hasDesc = true
+		}
+		descs = append(descs, desc)
+	}
+
+	schema.Extensions = map[string]any{
+		extEnumVarNames: names,
+	}
+	if hasDesc {
+		schema.Extensions[extEnumDescriptions] = descs
+	}
+
+	// 类型本身的注释作为描述
+	schema.Description = p.namedDoc(named.Obj().Pos(), named.Obj().Name())
+
+	return schema
+}
+
+// constantValue 将常量值转换为可序列化的 Go 值
+func constantValue(v constant.Value) any {
+	switch v.Kind() {
+	case constant.String:
+		return constant.StringVal(v)
+	case constant.Bool:
+		return constant.BoolVal(v)
+	case constant.Int:
+		if i, ok := constant.Int64Val(v); ok {
+			return i
+		}
+		if u, ok := constant.Uint64Val(v); ok {
+			return u
+		}
+	case constant.Float:
+		f, _ := constant.Float64Val(v)
+		return f
+	}
+	return v.ExactString()
+}
@@ -0,0 +1,36 @@
+package parser
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestEnumSchema(t *testing.T) {
+	openapi, _ := parseApp(t, Options{})
+	account := componentSchema(t, openapi, "Account")
+
+	tests := []struct {
+		field     string
+		want      []any
+		wantNames []string
+	}{
+		// 扫描范围内的类型收集常量作为枚举值
+		{"status", []any{"active", "disabled"}, []string{"StatusActive", "StatusDisabled"}},
+		// 标准库中的类型 (http.SameSite) 不收集枚举
+		{"site", nil, nil},
+	}
+
+	for _, tt := range tests {
+		prop, ok := account.Properties.Get(tt.field)
+		if !ok {
+			t.Fatalf("Account 缺少属性 %s", tt.field)
+		}
+		if !slices.Equal(prop.Enum, tt.want) {
+			t.Errorf("%s 的枚举值 = %v, want %v", tt.field, prop.Enum, tt.want)
+		}
+		names, _ := prop.Extensions["x-enum-varnames"].([]string)
+		if !slices.Equal(names, tt.wantNames) {
+			t.Errorf("%s 的 x-enum-varnames = %v, want %v", tt.field, names, tt.wantNames)
+		}
+	}
+}
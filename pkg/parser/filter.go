@@ -0,0 +1,63 @@
+package parser
+
+import (
+	"go/ast"
+	"slices"
+	"strings"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+// matchPackage 检查包路径是否匹配任一模式
+// 模式为完整包路径，或以 "/..." 结尾表示该路径及其所有子包
+func matchPackage(pkgPath string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+			if pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/") {
+				return true
+			}
+		} else if pkgPath == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// includesPackage 检查是否收录该包中的接口 (Options.Packages)
+func (p *Processor) includesPackage(pkgPath string) bool {
+	return len(p.opts.Packages) == 0 || matchPackage(pkgPath, p.opts.Packages)
+}
+
+// includesOperation 按 @Tags 检查是否收录该接口 (Options.IncludeTags、Options.ExcludeTags)
+// 在解析接口之前判断，被过滤的接口不会生成 Schema 或诊断信息
+func (p *Processor) includesOperation(doc *ast.CommentGroup) bool {
+	if len(p.opts.IncludeTags) == 0 && len(p.opts.ExcludeTags) == 0 {
+		return true
+	}
+
+	var tags []string
+	for _, comment := range doc.List {
+		if tag, content := parseCommentLine(comment.Text); tag == TagTags {
+			tags = append(tags, parseTags(content)...)
+		}
+	}
+
+	included := len(p.opts.IncludeTags) == 0
+	for _, t := range tags {
+		if slices.Contains(p.opts.ExcludeTags, t) {
+			return false
+		}
+		included = included || slices.Contains(p.opts.IncludeTags, t)
+	}
+	return included
+}
+
+// filterGlobalTags 从全局标签列表中移除被排除的标签
+func (p *Processor) filterGlobalTags() {
+	if len(p.opts.ExcludeTags) == 0 {
+		return
+	}
+	p.OpenAPI.Tags = slices.DeleteFunc(p.OpenAPI.Tags, func(t *model.Tag) bool {
+		return slices.Contains(p.opts.ExcludeTags, t.Name)
+	})
+}
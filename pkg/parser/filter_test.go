@@ -0,0 +1,59 @@
+package parser
+
+import "testing"
+
+func TestMatchPackage(t *testing.T) {
+	tests := []struct {
+		pkgPath  string
+		patterns []string
+		want     bool
+	}{
+		{"example.com/app/api", []string{"example.com/app/api"}, true},
+		{"example.com/app/api/v1", []string{"example.com/app/api"}, false},
+		{"example.com/app/api", []string{"example.com/app/api/..."}, true},
+		{"example.com/app/api/v1", []string{"example.com/app/api/..."}, true},
+		{"example.com/app/apiv2", []string{"example.com/app/api/..."}, false},
+		{"example.com/app/admin", []string{"example.com/app/api", "example.com/app/admin"}, true},
+		{"example.com/app/api", nil, false},
+	}
+
+	for _, tt := range tests {
+		if got := matchPackage(tt.pkgPath, tt.patterns); got != tt.want {
+			t.Errorf("matchPackage(%q, %v) = %v, want %v", tt.pkgPath, tt.patterns, got, tt.want)
+		}
+	}
+}
+
+func TestFilterOperations(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want map[string]bool
+	}{
+		{"不过滤", Options{}, map[string]bool{"/public": true, "/internal": true, "/untagged": true, "/typo": true}},
+		{"IncludeTags", Options{IncludeTags: []string{"users"}}, map[string]bool{"/public": true, "/internal": true}},
+		{"ExcludeTags 优先", Options{IncludeTags: []string{"users"}, ExcludeTags: []string{"internal"}}, map[string]bool{"/public": true}},
+		{"仅 ExcludeTags", Options{ExcludeTags: []string{"internal"}}, map[string]bool{"/public": true, "/untagged": true, "/typo": true}},
+		{"Packages", Options{Packages: []string{"example.com/app/filter"}}, map[string]bool{"/public": true, "/internal": true, "/untagged": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			openapi, _ := parseApp(t, tt.opts)
+			for _, path := range []string{"/public", "/internal", "/untagged", "/typo"} {
+				if _, got := openapi.Paths.Paths.Get(path); got != tt.want[path] {
+					t.Errorf("接口 %s 存在 = %v, want %v", path, got, tt.want[path])
+				}
+			}
+		})
+	}
+
+	t.Run("排除的标签从全局标签中移除", func(t *testing.T) {
+		openapi, _ := parseApp(t, Options{ExcludeTags: []string{"internal"}})
+		for _, tag := range openapi.Tags {
+			if tag.Name == "internal" {
+				t.Errorf("全局标签中仍包含被排除的 internal")
+			}
+		}
+	})
+}
@@ -1,29 +1,95 @@
 package parser
 
 import (
+	"fmt"
 	"go/ast"
+	"go/token"
 	"strings"
 
 	"github.com/promonkeyli/goas/pkg/model"
 	"golang.org/x/tools/go/packages"
 )
 
-// parseGlobalAnnotations 解析 main 包中 main 函数的全局注释
-func (p *Processor) parseGlobalAnnotations(pkg *packages.Package, file *ast.File, fn *ast.FuncDecl) {
-	if fn.Doc == nil {
+// 全局注释来源的优先级，未指定 GlobalPackage 时使用优先级最高的来源所在的包
+const (
+	// 带有全局注解 (@Title、@Version、@Server 等) 的 package 注释
+	globalFromPackageDoc = iota
+	// main 包的 main 函数
+	globalFromMain
+	// @GoasGlobal 标记的声明或 package 注释
+	globalFromMarker
+)
+
+// globalSource 全局注释的来源
+type globalSource struct {
+	pkg *packages.Package
+	doc *ast.CommentGroup
+	// 来源的优先级: globalFromPackageDoc、globalFromMain、globalFromMarker
+	priority int
+}
+
+// addGlobalSource 记录全局注释的来源
+func (p *Processor) addGlobalSource(pkg *packages.Package, doc *ast.CommentGroup, priority int) {
+	p.globalSources = append(p.globalSources, globalSource{pkg: pkg, doc: doc, priority: priority})
+}
+
+// parseGlobals 选择全局注释所在的包并解析该包中的所有来源
+// - 配置了 Options.GlobalPackage 时使用该包
+// - 否则依次优先使用 @GoasGlobal 标记的来源、main 函数、带有全局注解的 package 注释，同级取扫描到的第一个包
+// 其他包中的全局注释被忽略，未指定 GlobalPackage 时给出警告
+func (p *Processor) parseGlobals() {
+	if len(p.globalSources) == 0 && p.opts.GlobalPackage == "" {
 		return
 	}
 
+	chosen := p.opts.GlobalPackage
+	if chosen == "" {
+		best := p.globalSources[0]
+		for _, src := range p.globalSources[1:] {
+			if src.priority > best.priority {
+				best = src
+			}
+		}
+		chosen = best.pkg.PkgPath
+	}
+
+	found := false
+	for _, src := range p.globalSources {
+		found = found || src.pkg.PkgPath == chosen
+	}
+	if !found {
+		p.report(token.NoPos, SeverityWarning, fmt.Sprintf("包 %s 中没有全局注解", chosen))
+		return
+	}
+
+	for _, src := range p.globalSources {
+		if src.pkg.PkgPath != chosen {
+			if p.opts.GlobalPackage != "" {
+				continue
+			}
+			p.report(src.doc.Pos(), SeverityWarning, fmt.Sprintf("全局注解已从包 %s 读取，忽略包 %s 中的全局注解 (可通过 GlobalPackage 指定)", chosen, src.pkg.PkgPath))
+			continue
+		}
+		p.parseGlobalAnnotations(src.doc)
+	}
+}
+
+// parseGlobalAnnotations 解析全局注释
+func (p *Processor) parseGlobalAnnotations(doc *ast.CommentGroup) {
 	// 当前正在解析的 Tag (用于处理 @Tag.* 分组)
 	var currentTag *model.Tag
 
-	for _, comment := range fn.Doc.List {
+	for _, comment := range doc.List {
 		tag, content := parseCommentLine(comment.Text)
 		if tag == "" {
 			continue
 		}
+		p.pos = comment.Pos()
 
 		switch tag {
+		// 来源标记本身
+		case TagGoasGlobal:
+
 		// ========== 根配置 ==========
 		case TagOpenAPI:
 			p.OpenAPI.OpenAPI = content
@@ -86,28 +152,28 @@ func (p *Processor) parseGlobalAnnotations(pkg *packages.Package, file *ast.File
 			currentTag = &model.Tag{Name: content}
 			p.OpenAPI.Tags = append(p.OpenAPI.Tags, currentTag)
 		case TagTagSummary:
-			if currentTag != nil {
+			if p.requireTag(currentTag, tag) {
 				currentTag.Summary = content
 			}
 		case TagTagDesc:
-			if currentTag != nil {
+			if p.requireTag(currentTag, tag) {
 				currentTag.Description = content
 			}
 		case TagTagParent:
-			if currentTag != nil {
+			if p.requireTag(currentTag, tag) {
 				currentTag.Parent = content
 			}
 		case TagTagKind:
-			if currentTag != nil {
+			if p.requireTag(currentTag, tag) {
 				currentTag.Kind = content
 			}
 		case TagTagDocsURL:
-			if currentTag != nil {
+			if p.requireTag(currentTag, tag) {
 				p.ensureTagExternalDocs(currentTag)
 				currentTag.ExternalDocs.URL = content
 			}
 		case TagTagDocsDesc:
-			if currentTag != nil {
+			if p.requireTag(currentTag, tag) {
 				p.ensureTagExternalDocs(currentTag)
 				currentTag.ExternalDocs.Description = content
 			}
@@ -119,6 +185,9 @@ func (p *Processor) parseGlobalAnnotations(pkg *packages.Package, file *ast.File
 			p.parseSecurityScope(content)
 		case TagSecurity:
 			p.parseGlobalSecurity(content)
+
+		default:
+			p.warnf("未知的全局注解 %s", tag)
 		}
 	}
 }
@@ -143,6 +212,15 @@ func (p *Processor) ensureComponents() {
 	}
 }
 
+// requireTag 检查 @Tag.* 注解之前是否已经声明了 @Tag.Name
+func (p *Processor) requireTag(current *model.Tag, tag string) bool {
+	if current == nil {
+		p.warnf("%s 之前缺少 @Tag.Name，已忽略", tag)
+		return false
+	}
+	return true
+}
+
 func (p *Processor) ensureTagExternalDocs(tag *model.Tag) {
 	if tag.ExternalDocs == nil {
 		tag.ExternalDocs = &model.ExternalDocs{}
@@ -161,6 +239,7 @@ func (p *Processor) appendDescription(desc *string, content string) {
 func (p *Processor) parseExternalDocs(content string) {
 	parts := splitParams(content)
 	if len(parts) < 1 {
+		p.warnf("@ExternalDocs 缺少 url，已忽略")
 		return
 	}
 
@@ -182,6 +261,7 @@ func (p *Processor) parseExternalDocs(content string) {
 func (p *Processor) parseSecurityScheme(content string) {
 	params := splitParams(content)
 	if len(params) < 2 {
+		p.warnf("@SecurityScheme 格式错误，应为 <name> <type> [args...]: %q", content)
 		return
 	}
 
@@ -214,7 +294,11 @@ func (p *Processor) parseSecurityScheme(content string) {
 			if len(params) > 3 {
 				authURL := params[3]
 				scheme.Flows = p.createOAuthFlow(flowType, authURL)
+			} else {
+				p.warnf("@SecurityScheme %s 缺少 OAuth2 授权地址", name)
 			}
+		} else {
+			p.warnf("@SecurityScheme %s 缺少 OAuth2 流程类型", name)
 		}
 	case "openidconnect":
 		scheme.Type = "openIdConnect"
@@ -223,6 +307,8 @@ func (p *Processor) parseSecurityScheme(content string) {
 		}
 	case "mutualtls":
 		scheme.Type = "mutualTLS"
+	default:
+		p.warnf("@SecurityScheme %s 的类型 %q 未知，可选值: apiKey, http, oauth2, openIdConnect, mutualTLS", name, params[1])
 	}
 
 	p.ensureComponents()
@@ -253,6 +339,8 @@ func (p *Processor) createOAuthFlow(flowType, authURL string) *model.OAuthFlows
 	case "authorizationcode", "authorizationCode":
 		flow.AuthorizationURL = authURL
 		flows.AuthorizationCode = flow
+	default:
+		p.warnf("未知的 OAuth2 流程类型 %q，可选值: implicit, password, clientCredentials, authorizationCode", flowType)
 	}
 
 	return flows
@@ -263,6 +351,7 @@ func (p *Processor) createOAuthFlow(flowType, authURL string) *model.OAuthFlows
 func (p *Processor) parseSecurityScope(content string) {
 	params := splitParams(content)
 	if len(params) < 3 {
+		p.warnf("@SecurityScope 格式错误，应为 <scheme> <scope> <description>: %q", content)
 		return
 	}
 
@@ -271,12 +360,13 @@ func (p *Processor) parseSecurityScope(content string) {
 	scopeDesc := strings.Join(params[2:], " ")
 
 	p.ensureComponents()
-	if p.OpenAPI.Components.SecuritySchemes == nil {
+	scheme, ok := p.OpenAPI.Components.SecuritySchemes[schemeName]
+	if !ok {
+		p.warnf("@SecurityScope 引用了未声明的安全方案 %q (需先使用 @SecurityScheme 声明)", schemeName)
 		return
 	}
-
-	scheme, ok := p.OpenAPI.Components.SecuritySchemes[schemeName]
-	if !ok || scheme.Flows == nil {
+	if scheme.Flows == nil {
+		p.warnf("@SecurityScope 引用的安全方案 %q 不是 oauth2 类型", schemeName)
 		return
 	}
 
@@ -301,11 +391,13 @@ func (p *Processor) parseSecurityScope(content string) {
 func (p *Processor) parseGlobalSecurity(content string) {
 	params := splitParams(content)
 	if len(params) < 1 {
+		p.warnf("@Security 缺少安全方案名称，已忽略")
 		return
 	}
 
 	name := params[0]
 	scopes := params[1:]
+	p.securityRefs = append(p.securityRefs, annotationRef{name: name, pos: p.pos})
 
 	requirement := model.SecurityRequirement{
 		name: scopes,
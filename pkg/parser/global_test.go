@@ -0,0 +1,48 @@
+package parser
+
+import "testing"
+
+func TestGlobalSource(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      Options
+		wantTitle string
+		wantWarn  bool
+	}{
+		// main 优先于带全局注解的 package 注释，只含接口注解的 package 注释不参与选择
+		{"默认优先 main", Options{}, "Main", true},
+		{"GlobalPackage 指定", Options{GlobalPackage: "example.com/globals/lib"}, "Lib", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			openapi, diags := loadTestdata(t, "globals").Parse(tt.opts)
+			if openapi.Info.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", openapi.Info.Title, tt.wantTitle)
+			}
+			if got := hasDiagnostic(diags, "忽略包 example.com/globals/lib 中的全局注解"); got != tt.wantWarn {
+				t.Errorf("忽略 lib 的警告 = %v, want %v", got, tt.wantWarn)
+			}
+			if hasDiagnostic(diags, "example.com/globals/api") || hasDiagnostic(diags, "未知的全局注解") {
+				t.Errorf("api 的 package 注释不应作为全局注释的来源: %v", diags)
+			}
+			if diags.HasErrors() {
+				t.Errorf("不应有错误: %v", diags)
+			}
+		})
+	}
+}
+
+func TestGlobalMarker(t *testing.T) {
+	openapi, diags := loadTestdata(t, "marked").Parse(Options{})
+
+	if openapi.Info.Title != "Meta" || openapi.Info.Version != "2.0" {
+		t.Errorf("Info = %q %q, want Meta 2.0", openapi.Info.Title, openapi.Info.Version)
+	}
+	if len(openapi.Servers) != 1 || openapi.Servers[0].URL != "https://api.example.com" {
+		t.Errorf("Servers = %+v, want 合并同包中的 @Server", openapi.Servers)
+	}
+	if !hasDiagnostic(diags, "忽略包 example.com/marked/cmd 中的全局注解") {
+		t.Errorf("缺少忽略 main 的警告: %v", diags)
+	}
+}
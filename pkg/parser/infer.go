@@ -0,0 +1,174 @@
+package parser
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/promonkeyli/goas/pkg/model"
+	"golang.org/x/tools/go/packages"
+)
+
+// ginBinding gin 绑定方法绑定的参数位置
+type ginBinding struct {
+	// body、query、path、header，auto 表示按请求方法决定 (GET 绑定查询参数，其他绑定请求体)
+	in string
+	// 请求体的 Content-Type，仅 body
+	contentType string
+}
+
+// gin.Context 的绑定方法: c.ShouldBindJSON(&req)
+var ginBindings = map[string]ginBinding{
+	"ShouldBindJSON":         {in: "body", contentType: "application/json"},
+	"BindJSON":               {in: "body", contentType: "application/json"},
+	"ShouldBindBodyWithJSON": {in: "body", contentType: "application/json"},
+	"ShouldBindXML":          {in: "body", contentType: "application/xml"},
+	"BindXML":                {in: "body", contentType: "application/xml"},
+	"ShouldBindYAML":         {in: "body", contentType: "application/x-yaml"},
+	"BindYAML":               {in: "body", contentType: "application/x-yaml"},
+	"ShouldBindTOML":         {in: "body", contentType: "application/toml"},
+	"BindTOML":               {in: "body", contentType: "application/toml"},
+	"ShouldBindQuery":        {in: "query"},
+	"BindQuery":              {in: "query"},
+	"ShouldBindUri":          {in: "path"},
+	"BindUri":                {in: "path"},
+	"ShouldBindHeader":       {in: "header"},
+	"BindHeader":             {in: "header"},
+	"ShouldBind":             {in: "auto"},
+	"Bind":                   {in: "auto"},
+}
+
+// ginKeyParam gin.Context 按名称读取参数的方法对应的参数
+type ginKeyParam struct {
+	in string
+	// 是否是数组参数 (c.QueryArray)
+	array bool
+}
+
+// gin 绑定结构体到各位置时使用的 tag
+var ginParamTags = map[string][]string{
+	"query":  {"form"},
+	"path":   {"uri"},
+	"header": {"header"},
+}
+
+// gin.Context 按名称读取参数的方法: c.Param("id")、c.Query("page")
+var ginKeyParams = map[string]ginKeyParam{
+	"Param":         {in: "path"},
+	"Query":         {in: "query"},
+	"DefaultQuery":  {in: "query"},
+	"GetQuery":      {in: "query"},
+	"QueryArray":    {in: "query", array: true},
+	"GetQueryArray": {in: "query", array: true},
+	"GetHeader":     {in: "header"},
+	"Cookie":        {in: "cookie"},
+}
+
+// inferRequest 从处理函数体中的 gin 绑定调用推断请求参数和请求体
+// 只补充注解中没有声明的参数 (按名称和位置判断) 以及请求体，显式的注解始终优先
+func (p *Processor) inferRequest(pkg *packages.Package, fn *ast.FuncDecl, op *model.Operation, routes []route) {
+	if fn.Body == nil || pkg.TypesInfo == nil {
+		return
+	}
+	info := pkg.TypesInfo
+
+	// ShouldBind / Bind 对 GET 请求绑定查询参数
+	autoIn := "query"
+	for _, r := range routes {
+		if r.method != "get" {
+			autoIn = "body"
+		}
+	}
+
+	// 函数体中的闭包同样检查 (返回 gin.HandlerFunc 的工厂函数)
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		recv := info.TypeOf(sel.X)
+		if recv == nil || !isNamedType(recv, ginPkgPath, "Context") {
+			return true
+		}
+
+		if binding, ok := ginBindings[sel.Sel.Name]; ok {
+			in, contentType := binding.in, binding.contentType
+			if in == "auto" {
+				in = autoIn
+			}
+			p.inferBinding(op, info.TypeOf(call.Args[0]), in, contentType)
+		} else if keyParam, ok := ginKeyParams[sel.Sel.Name]; ok {
+			p.inferKeyParam(op, call, info, keyParam)
+		}
+		return true
+	})
+}
+
+// inferBinding 根据绑定的结构体类型推断请求体或参数
+func (p *Processor) inferBinding(op *model.Operation, t types.Type, in, contentType string) {
+	if t == nil {
+		return
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	if in == "body" {
+		if op.RequestBody != nil {
+			return
+		}
+		if contentType == "" {
+			contentType = "application/json"
+			if len(p.acceptTypes) > 0 {
+				contentType = p.acceptTypes[0]
+			}
+		}
+		op.RequestBody = &model.RequestBody{
+			Required: true,
+			Content: map[string]*model.MediaType{
+				contentType: {Schema: p.namedTypeSchema(t)},
+			},
+		}
+		return
+	}
+
+	for _, param := range p.structParams(t, in, ginParamTags[in]) {
+		if !hasParam(op, param.Name, param.In) {
+			op.Parameters = append(op.Parameters, param)
+		}
+	}
+}
+
+// inferKeyParam 根据按名称读取参数的调用推断参数: c.Query("page") -> page (query, string)
+func (p *Processor) inferKeyParam(op *model.Operation, call *ast.CallExpr, info *types.Info, keyParam ginKeyParam) {
+	name, ok := constString(call.Args[0], info)
+	if !ok || name == "" || hasParam(op, name, keyParam.in) {
+		return
+	}
+
+	schema := &model.Schema{Type: "string"}
+	if keyParam.array {
+		schema = &model.Schema{Type: "array", Items: schema}
+	}
+	// c.DefaultQuery("page", "1")
+	if len(call.Args) == 2 {
+		if value, ok := constString(call.Args[1], info); ok {
+			schema.Default = value
+		}
+	}
+
+	param := &model.Parameter{
+		Name:     name,
+		In:       keyParam.in,
+		Required: keyParam.in == "path", // path 参数始终必填
+		Schema:   schema,
+	}
+	if keyParam.array {
+		param.Style = "form"
+		param.Explode = ptrTo(true)
+	}
+	op.Parameters = append(op.Parameters, param)
+}
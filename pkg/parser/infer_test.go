@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestInferParams(t *testing.T) {
+	openapi, _ := parseApp(t, Options{InferParams: true})
+
+	t.Run("绑定查询参数与请求头", func(t *testing.T) {
+		op := findOperation(openapi, "/search", "get")
+		if op == nil {
+			t.Fatal("未找到接口 /search")
+		}
+		// 注释声明的 q 优先于推断的同名参数
+		want := []string{"query:q", "query:page", "query:tags", "header:X-Trace"}
+		if got := paramKeys(op); !slices.Equal(got, want) {
+			t.Fatalf("参数 = %v, want %v", got, want)
+		}
+		if op.Parameters[0].Description != "keyword" {
+			t.Errorf("q 的描述 = %q, want 注释中的 %q", op.Parameters[0].Description, "keyword")
+		}
+		if page := op.Parameters[1].Schema; fmt.Sprint(page.Default) != "1" {
+			t.Errorf("page 的默认值 = %v, want 1", page.Default)
+		}
+	})
+
+	t.Run("闭包中的路径参数与请求体", func(t *testing.T) {
+		op := findOperation(openapi, "/infer/users/{id}", "put")
+		if op == nil {
+			t.Fatal("未找到接口 /infer/users/{id}")
+		}
+		if got := paramKeys(op); !slices.Equal(got, []string{"path:id"}) {
+			t.Errorf("参数 = %v, want [path:id]", got)
+		}
+		if op.RequestBody == nil {
+			t.Fatal("未推断出请求体")
+		}
+		media, ok := op.RequestBody.Content["application/json"]
+		if !ok || media.Schema == nil || media.Schema.Ref != "#/components/schemas/UpdateReq" {
+			t.Errorf("请求体 = %+v, want UpdateReq", op.RequestBody.Content)
+		}
+	})
+
+	t.Run("未开启 InferParams", func(t *testing.T) {
+		openapi, _ := parseApp(t, Options{})
+		op := findOperation(openapi, "/search", "get")
+		if got := paramKeys(op); !slices.Equal(got, []string{"query:q"}) {
+			t.Errorf("参数 = %v, want [query:q]", got)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/promonkeyli/goas/pkg/model"
+	"golang.org/x/tools/go/packages"
+)
+
+// schemaAnnotation 类型声明上的 @Schema 注解
+//
+//	// Money 金额，序列化为字符串
+//	// @Schema string decimal
+//	type Money struct{ cents int64 }
+type schemaAnnotation struct {
+	// 声明类型所在的包，用于解析注解中的类型名
+	pkg *packages.Package
+	// 注解内容 (去掉 @Schema 前缀)，格式: <类型> [format]
+	value string
+	// 注解所在位置
+	pos token.Pos
+}
+
+// declaredTypeSchema 获取命名类型自身声明的 JSON 表现，优先级:
+// TypeOverrides > 内置常见类型 > @Schema 注解 > json.Marshaler / encoding.TextMarshaler
+// 均未命中时返回 nil，由调用方按底层类型解析
+func (p *Processor) declaredTypeSchema(named *types.Named) *model.Schema {
+	if schema := p.knownTypeSchema(named); schema != nil {
+		return schema
+	}
+	if schema := p.annotatedTypeSchema(named); schema != nil {
+		return schema
+	}
+	return p.marshalerSchema(named)
+}
+
+// annotatedTypeSchema 根据类型声明上的 @Schema 注解生成 Schema
+func (p *Processor) annotatedTypeSchema(named *types.Named) *model.Schema {
+	obj := named.Obj()
+	ann, ok := p.schemaAnnotations[obj.Pos()]
+	if !ok || p.resolvingAnnotations[obj] {
+		return nil
+	}
+
+	// 防止注解引用类型自身导致死循环
+	p.resolvingAnnotations[obj] = true
+	defer delete(p.resolvingAnnotations, obj)
+
+	prevPos := p.pos
+	p.pos = ann.pos
+	defer func() { p.pos = prevPos }()
+
+	fields := strings.Fields(ann.value)
+	if len(fields) == 0 {
+		p.warnf("@Schema 缺少类型，格式: @Schema <type> [format]")
+		return nil
+	}
+
+	schema := p.resolveTypeSchema(ann.pkg, fields[0])
+	if len(fields) > 1 {
+		schema.Format = fields[1]
+	}
+	if schema.Ref == "" {
+		schema.Description = p.namedDoc(obj.Pos(), obj.Name())
+	}
+	return schema
+}
+
+// marshalerSchema 处理自定义序列化的类型
+// - 实现 json.Marshaler: 无法从源码推断输出结构，生成无约束占位 Schema 并提示使用 @Schema 指定
+// - 实现 encoding.TextMarshaler: encoding/json 将其编码为字符串
+// 与 encoding/json 一致，json.Marshaler 优先
+func (p *Processor) marshalerSchema(named *types.Named) *model.Schema {
+	obj := named.Obj()
+
+	switch {
+	case hasMarshalMethod(named, "MarshalJSON"):
+		if !p.warnedMarshalers[obj] {
+			p.warnedMarshalers[obj] = true
+			p.report(obj.Pos(), SeverityWarning, fmt.Sprintf(
+				"类型 %s 实现了 json.Marshaler，无法推断其 JSON 结构，已生成无约束 Schema (可在类型声明上使用 @Schema 或配置 TypeOverrides 指定)", obj.Name()))
+		}
+		return &model.Schema{Description: p.namedDoc(obj.Pos(), obj.Name())}
+
+	case hasMarshalMethod(named, "MarshalText"):
+		return &model.Schema{
+			Type:        "string",
+			Description: p.namedDoc(obj.Pos(), obj.Name()),
+		}
+	}
+	return nil
+}
+
+// hasMarshalMethod 检查类型 (或其指针) 的方法集中是否有 func() ([]byte, error) 签名的指定方法
+// 嵌入字段提升上来的方法同样生效，与 encoding/json 的行为一致
+func hasMarshalMethod(t types.Type, name string) bool {
+	sel := types.NewMethodSet(types.NewPointer(t)).Lookup(nil, name)
+	if sel == nil {
+		return false
+	}
+
+	sig, ok := sel.Type().(*types.Signature)
+	if !ok || sig.Params().Len() != 0 || sig.Results().Len() != 2 {
+		return false
+	}
+
+	data, ok := sig.Results().At(0).Type().(*types.Slice)
+	return ok && isByteSlice(data) &&
+		types.Identical(sig.Results().At(1).Type(), types.Universe.Lookup("error").Type())
+}
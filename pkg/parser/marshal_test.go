@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+func TestMarshalerSchema(t *testing.T) {
+	openapi, diags := parseApp(t, Options{})
+	marshalers := componentSchema(t, openapi, "Marshalers")
+
+	tests := []struct {
+		name       string
+		field      string
+		wantType   any
+		wantFormat string
+	}{
+		{"TextMarshaler", "code", "string", ""},
+		{"json.Marshaler 生成无约束 Schema", "blob", nil, ""},
+		{"@Schema 注解", "amount", "string", "decimal"},
+		{"嵌入字段提升的方法", "wrapped", "string", ""},
+		{"指针接收者的方法", "token", "string", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prop, ok := marshalers.Properties.Get(tt.field)
+			if !ok {
+				t.Fatalf("Marshalers 缺少属性 %s", tt.field)
+			}
+			if prop.Type != tt.wantType || prop.Format != tt.wantFormat {
+				t.Errorf("%s = {type: %v, format: %q}, want {type: %v, format: %q}", tt.field, prop.Type, prop.Format, tt.wantType, tt.wantFormat)
+			}
+			if prop.Properties != nil {
+				t.Errorf("%s 不应按底层结构体展开", tt.field)
+			}
+		})
+	}
+
+	if !hasDiagnostic(diags, "类型 Blob 实现了 json.Marshaler") {
+		t.Errorf("缺少 json.Marshaler 的警告")
+	}
+	for _, name := range []string{"Code", "Blob", "Amount", "Wrapped", "Token"} {
+		if _, ok := openapi.Components.Schemas[name]; ok {
+			t.Errorf("%s 不应生成 Components Schema", name)
+		}
+	}
+}
+
+func TestSchemaAnnotationOverride(t *testing.T) {
+	// TypeOverrides 优先于 @Schema 注解
+	openapi, _ := parseApp(t, Options{
+		TypeOverrides: map[string]*model.Schema{"example.com/app/models.Amount": {Type: "number"}},
+	})
+	amount, _ := componentSchema(t, openapi, "Marshalers").Properties.Get("amount")
+	if amount.Type != "number" || amount.Format != "" {
+		t.Errorf("amount = {type: %v, format: %q}, want {type: number}", amount.Type, amount.Format)
+	}
+}
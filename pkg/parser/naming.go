@@ -0,0 +1,356 @@
+package parser
+
+import (
+	"fmt"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Schema 命名策略
+const (
+	// NamingShort 使用类型名 (Invoice)，与其他包的同名类型冲突时包路径最小的类型使用类型名，其他退化为 NamingPackage (默认)
+	NamingShort = "short"
+	// NamingPackage 使用包名限定的类型名 (billing.Invoice)
+	NamingPackage = "package"
+	// NamingFull 使用完整包路径限定的类型名 (github.com.acme.billing.Invoice)
+	NamingFull = "full"
+)
+
+// namingStrategies 命名策略按限定程度从低到高排列，名称冲突时依次尝试更完整的名称
+var namingStrategies = []string{NamingShort, NamingPackage, NamingFull}
+
+// Components 中 Schema 名称允许的字符: ^[a-zA-Z0-9\.\-_]+$
+var invalidSchemaNameChars = regexp.MustCompile(`[^a-zA-Z0-9.\-_]`)
+
+// typeKey 类型的唯一标识 (完整包路径 + 类型实参)，用作 GeneratedSchemas 的 Key
+// 如: "github.com/acme/billing.Invoice", "github.com/acme/api.Response[github.com/acme/billing.Invoice]"
+func typeKey(named *types.Named) string {
+	return types.TypeString(named, nil)
+}
+
+// componentName 为命名类型 (包括泛型实例) 分配 Components 中的 Schema 名称
+// 从配置的命名策略开始，名称已被其他类型占用时使用更完整的限定名
+func (p *Processor) componentName(named *types.Named) string {
+	start := 0
+	for i, strategy := range namingStrategies {
+		if strategy == p.opts.Naming {
+			start = i
+		}
+	}
+
+	var candidates []string
+	for _, strategy := range namingStrategies[start:] {
+		candidates = append(candidates, p.schemaTypeName(named, strategy))
+	}
+	name := p.claimComponentName(typeKey(named), candidates...)
+
+	// 自定义的 GenericNamer 可能生成不符合规范的名称
+	// 显式选择的内置命名方式 (如 GenericNamingGuillemet) 是用户的决定，不再提示
+	if p.opts.GenericNamer != nil && invalidSchemaNameChars.MatchString(name) && !p.warnedSchemaNames[name] {
+		p.warnedSchemaNames[name] = true
+		p.warnf("GenericNamer 生成的 Schema 名称 %q 不符合 OpenAPI Components 的键名规则 ^[a-zA-Z0-9.\\-_]+$，部分工具可能无法解析", name)
+	}
+	return name
+}
+
+// reserveComponentNames 为项目中同名的结构体预留 Schema 名称
+// NamingShort 下 billing.Invoice 与 legacy.Invoice 只有一个能使用 Invoice，
+// 预先按包路径排序选出使用者，使结果不依赖接口和字段的解析顺序
+// 只考虑会注册到 Components 的结构体 (排除常见类型、@Schema 注解与自定义序列化的类型)
+func (p *Processor) reserveComponentNames() {
+	if p.opts.Naming != "" && p.opts.Naming != NamingShort {
+		return
+	}
+
+	// Key: 类型名, Value: 包路径最小的同名结构体
+	owners := make(map[string]*types.Named)
+	shared := make(map[string]bool)
+	for pkgPath, pkg := range p.PackagesMap {
+		if pkg.Types == nil || !p.isScannedPackage(pkgPath) {
+			continue
+		}
+		scope := pkg.Types.Scope()
+		for _, name := range scope.Names() {
+			obj, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok || obj.IsAlias() {
+				continue
+			}
+			named, ok := obj.Type().(*types.Named)
+			if !ok || !p.isComponentStruct(named) {
+				continue
+			}
+			owner, ok := owners[name]
+			if !ok {
+				owners[name] = named
+				continue
+			}
+			shared[name] = true
+			if pkgPath < owner.Obj().Pkg().Path() {
+				owners[name] = named
+			}
+		}
+	}
+
+	for name := range shared {
+		p.componentOwners[name] = typeKey(owners[name])
+	}
+}
+
+// isComponentStruct 检查命名类型是否是会注册到 Components 的非泛型结构体
+func (p *Processor) isComponentStruct(named *types.Named) bool {
+	if _, ok := named.Underlying().(*types.Struct); !ok || named.TypeParams().Len() > 0 {
+		return false
+	}
+	if _, ok := p.schemaAnnotations[named.Obj().Pos()]; ok {
+		return false
+	}
+	return p.knownTypeSchema(named) == nil &&
+		!hasMarshalMethod(named, "MarshalJSON") && !hasMarshalMethod(named, "MarshalText")
+}
+
+// claimComponentName 为 key 对应的类型占用第一个可用的候选名称
+// 所有候选名称都被占用时追加数字后缀
+func (p *Processor) claimComponentName(key string, candidates ...string) string {
+	for i, name := range candidates {
+		if owner, taken := p.componentOwners[name]; taken && owner != key {
+			continue
+		}
+		p.componentOwners[name] = key
+		if i > 0 {
+			p.warnf("Schema 名称 %q 已被 %s 使用，%s 改用 %q", candidates[0], p.componentOwners[candidates[0]], key, name)
+		}
+		return name
+	}
+
+	base := candidates[len(candidates)-1]
+	for n := 2; ; n++ {
+		name := fmt.Sprintf("%s%d", base, n)
+		if _, taken := p.componentOwners[name]; !taken {
+			p.componentOwners[name] = key
+			p.warnf("Schema 名称 %q 已被 %s 使用，%s 改用 %q", candidates[0], p.componentOwners[candidates[0]], key, name)
+			return name
+		}
+	}
+}
+
+// schemaTypeName 按命名策略生成类型的 Schema 名称
+// 泛型实例的类型实参按同一策略递归命名: Response[billing.Invoice] -> ResponseOfInvoice / billing.ResponseOfbilling.Invoice
+func (p *Processor) schemaTypeName(t types.Type, strategy string) string {
+	switch typ := t.(type) {
+	case *types.Named:
+		name := qualifiedTypeName(typ.Obj(), strategy)
+		if typ.TypeArgs().Len() == 0 {
+			return name
+		}
+		args := make([]string, typ.TypeArgs().Len())
+		for i := range args {
+			args[i] = p.schemaTypeName(typ.TypeArgs().At(i), strategy)
+		}
+		return p.genericSchemaName(name, args)
+
+	case *types.Alias:
+		return p.schemaTypeName(types.Unalias(typ), strategy)
+
+	case *types.Basic:
+		return typ.Name()
+
+	case *types.Pointer:
+		return p.schemaTypeName(typ.Elem(), strategy)
+
+	case *types.Slice:
+		return p.schemaTypeName(typ.Elem(), strategy) + "List"
+
+	case *types.Array:
+		return p.schemaTypeName(typ.Elem(), strategy) + "List"
+
+	case *types.Map:
+		return p.schemaTypeName(typ.Elem(), strategy) + "Map"
+
+	default:
+		return sanitizeSchemaName(types.TypeString(t, func(pkg *types.Package) string { return pkg.Name() }))
+	}
+}
+
+// qualifiedTypeName 按命名策略限定类型名
+func qualifiedTypeName(obj *types.TypeName, strategy string) string {
+	if obj.Pkg() == nil {
+		return obj.Name()
+	}
+	switch strategy {
+	case NamingPackage:
+		return obj.Pkg().Name() + "." + obj.Name()
+	case NamingFull:
+		return sanitizeSchemaName(strings.ReplaceAll(obj.Pkg().Path(), "/", ".") + "." + obj.Name())
+	default:
+		return obj.Name()
+	}
+}
+
+// GenericNamer 泛型实例的命名函数
+// base 为泛型类型名，args 为已按同一规则命名的类型实参 (嵌套泛型已展开)
+// 如 Response[PageList[User]] 调用为 ("Response", ["PageListOfUser"])
+type GenericNamer func(base string, args []string) string
+
+// 内置的泛型命名方式
+const (
+	// GenericNamingOf ResponseOfUser, MapOfstringAndUser (默认)
+	GenericNamingOf = "of"
+	// GenericNamingUnderscore Response_User, Map_string_User
+	GenericNamingUnderscore = "underscore"
+	// GenericNamingConcat ResponseUser, MapStringUser
+	GenericNamingConcat = "concat"
+	// GenericNamingGuillemet Response«User», Map«string,User» (springdoc / springfox 风格)
+	// 生成的名称不符合 OpenAPI Components 的键名规则，部分工具可能无法解析
+	GenericNamingGuillemet = "guillemet"
+)
+
+// GenericNamers 内置的泛型命名函数
+var GenericNamers = map[string]GenericNamer{
+	GenericNamingOf: func(base string, args []string) string {
+		return base + "Of" + strings.Join(args, "And")
+	},
+	GenericNamingUnderscore: func(base string, args []string) string {
+		return base + "_" + strings.Join(args, "_")
+	},
+	GenericNamingConcat: func(base string, args []string) string {
+		var b strings.Builder
+		b.WriteString(base)
+		for _, arg := range args {
+			if arg == "" {
+				continue
+			}
+			// 基本类型首字母大写: ResponseString
+			b.WriteString(strings.ToUpper(arg[:1]) + arg[1:])
+		}
+		return b.String()
+	},
+	GenericNamingGuillemet: func(base string, args []string) string {
+		return base + "«" + strings.Join(args, ",") + "»"
+	},
+}
+
+// genericSchemaName 拼接泛型实例的 Schema 名称
+// 优先使用 Options.GenericNamer，其次是 Options.GenericNaming 指定的内置命名方式，默认 GenericNamingOf
+func (p *Processor) genericSchemaName(base string, args []string) string {
+	if p.opts.GenericNamer != nil {
+		return p.opts.GenericNamer(base, args)
+	}
+	if namer, ok := GenericNamers[p.opts.GenericNaming]; ok {
+		return namer(base, args)
+	}
+	return GenericNamers[GenericNamingOf](base, args)
+}
+
+// sanitizeSchemaName 替换 Schema 名称中不允许的字符
+func sanitizeSchemaName(name string) string {
+	return invalidSchemaNameChars.ReplaceAllString(name, "_")
+}
+
+// lookupType 将注解中的类型表达式解析为 go/types 类型
+// 支持: 内置类型、pkg.Type、*T、[]T、map[K]V 以及泛型实例 Type[A, B]
+// 无法解析时返回 nil (如 integer、file 等注解专用的类型名)
+func (p *Processor) lookupType(pkg *packages.Package, expr string) types.Type {
+	expr = strings.TrimSpace(expr)
+
+	switch {
+	case strings.HasPrefix(expr, "*"):
+		if elem := p.lookupType(pkg, expr[1:]); elem != nil {
+			return types.NewPointer(elem)
+		}
+		return nil
+
+	case strings.HasPrefix(expr, "[]"):
+		if elem := p.lookupType(pkg, expr[2:]); elem != nil {
+			return types.NewSlice(elem)
+		}
+		return nil
+
+	case strings.HasPrefix(expr, "map["):
+		end := matchingBracket(expr, len("map"))
+		if end == -1 {
+			return nil
+		}
+		key := p.lookupType(pkg, expr[len("map["):end])
+		elem := p.lookupType(pkg, expr[end+1:])
+		if key == nil || elem == nil {
+			return nil
+		}
+		return types.NewMap(key, elem)
+	}
+
+	if base, args := parseGenericType(expr); base != "" {
+		return p.instantiate(pkg, base, args)
+	}
+
+	if obj, ok := types.Universe.Lookup(expr).(*types.TypeName); ok {
+		return obj.Type()
+	}
+	if obj := p.lookupTypeName(pkg, expr); obj != nil {
+		return obj.Type()
+	}
+	return nil
+}
+
+// instantiate 使用注解中的类型实参实例化泛型类型，失败时返回 nil
+func (p *Processor) instantiate(pkg *packages.Package, base string, args []string) types.Type {
+	obj := p.lookupTypeName(pkg, base)
+	if obj == nil {
+		return nil
+	}
+	named, ok := obj.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() != len(args) {
+		return nil
+	}
+
+	typeArgs := make([]types.Type, len(args))
+	for i, arg := range args {
+		if typeArgs[i] = p.lookupType(pkg, arg); typeArgs[i] == nil {
+			return nil
+		}
+	}
+
+	inst, err := types.Instantiate(nil, named, typeArgs, true)
+	if err != nil {
+		return nil
+	}
+	return inst
+}
+
+// lookupTypeName 在当前包或其导入的包中查找类型名 (如 User、model.User)
+func (p *Processor) lookupTypeName(pkg *packages.Package, name string) *types.TypeName {
+	if pkg == nil {
+		return nil
+	}
+	pkgPath, shortName := parseTypePath(pkg, name)
+
+	targetPkg := pkg
+	if pkgPath != pkg.PkgPath {
+		targetPkg = p.findPackage(pkgPath)
+	}
+	if targetPkg == nil || targetPkg.Types == nil {
+		return nil
+	}
+
+	obj, _ := targetPkg.Types.Scope().Lookup(shortName).(*types.TypeName)
+	return obj
+}
+
+// matchingBracket 查找 s[open] 处的 '[' 对应的 ']' 位置，未找到时返回 -1
+func matchingBracket(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
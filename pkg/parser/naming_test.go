@@ -0,0 +1,75 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenericSchemaName(t *testing.T) {
+	tests := []struct {
+		name string
+		opts Options
+		want string
+		warn bool
+	}{
+		{"默认", Options{}, "PageOfProfile", false},
+		{GenericNamingUnderscore, Options{GenericNaming: GenericNamingUnderscore}, "Page_Profile", false},
+		{GenericNamingConcat, Options{GenericNaming: GenericNamingConcat}, "PageProfile", false},
+		// 显式选择的内置命名方式不提示键名规则
+		{GenericNamingGuillemet, Options{GenericNaming: GenericNamingGuillemet}, "Page«Profile»", false},
+		// GenericNamer 优先于 GenericNaming，生成的名称不符合 Components 的键名规则时提示
+		{"GenericNamer", Options{
+			GenericNaming: GenericNamingUnderscore,
+			GenericNamer:  func(base string, args []string) string { return base + "<" + strings.Join(args, ",") + ">" },
+		}, "Page<Profile>", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			openapi, diags := parseApp(t, tt.opts)
+			componentSchema(t, openapi, tt.want)
+			if got := hasDiagnostic(diags, "不符合 OpenAPI Components 的键名规则"); got != tt.warn {
+				t.Errorf("键名规则警告 = %v, want %v", got, tt.warn)
+			}
+		})
+	}
+}
+
+func TestComponentNaming(t *testing.T) {
+	tests := []struct {
+		naming    string
+		wantAlpha string
+		wantZeta  string
+		warn      bool
+	}{
+		// zeta.Item 先被引用，但包路径更小的 alpha.Item 使用类型名
+		{NamingShort, "Item", "zeta.Item", true},
+		{NamingPackage, "alpha.Item", "zeta.Item", false},
+		{NamingFull, "example.com.app.dupes.alpha.Item", "example.com.app.dupes.zeta.Item", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.naming, func(t *testing.T) {
+			openapi, diags := parseApp(t, Options{Naming: tt.naming})
+			op := findOperation(openapi, "/dupes/items", "get")
+			if op == nil {
+				t.Fatal("未找到接口 /dupes/items")
+			}
+
+			refs := map[string]string{"200": tt.wantZeta, "400": tt.wantAlpha}
+			for code, want := range refs {
+				resp := op.Responses.Codes[code]
+				if resp == nil {
+					t.Fatalf("缺少 %s 响应", code)
+				}
+				if got := resp.Content["application/json"].Schema.Ref; got != "#/components/schemas/"+want {
+					t.Errorf("%s 响应引用 %q, want %q", code, got, want)
+				}
+				componentSchema(t, openapi, want)
+			}
+			if got := hasDiagnostic(diags, `Schema 名称 "Item" 已被 example.com/app/dupes/alpha.Item 使用`); got != tt.warn {
+				t.Errorf("名称冲突警告 = %v, want %v", got, tt.warn)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package parser
 
 import (
+	"fmt"
 	"go/ast"
+	"slices"
 	"strings"
 
 	"github.com/promonkeyli/goas/pkg/model"
@@ -10,15 +12,15 @@ import (
 
 // parseOperation 解析 Handler 函数上的接口注释，生成 Operation 对象
 func (p *Processor) parseOperation(pkg *packages.Package, file *ast.File, fn *ast.FuncDecl) {
-	if fn.Doc == nil {
+	if fn.Doc == nil || !p.includesOperation(fn.Doc) {
 		return
 	}
 
 	var (
-		routerPath   string
-		routerMethod string
-		ignore       bool
-		op           = &model.Operation{
+		// @Router 声明的路由，可以有多行
+		annotated []route
+		ignore    bool
+		op        = &model.Operation{
 			Responses: &model.Responses{
 				Codes: make(map[string]*model.Response),
 			},
@@ -30,11 +32,17 @@ func (p *Processor) parseOperation(pkg *packages.Package, file *ast.File, fn *as
 		if tag == "" {
 			continue
 		}
+		p.pos = comment.Pos()
 
 		switch tag {
 		// ========== 路由配置 ==========
 		case TagRouter:
-			routerPath, routerMethod = parseRouterPath(content)
+			routerPath, routerMethod := parseRouterPath(content)
+			if routerPath == "" {
+				p.warnf("@Router 缺少路径: %q", content)
+				continue
+			}
+			annotated = append(annotated, route{method: routerMethod, path: routerPath, pos: p.pos})
 		case TagId:
 			op.OperationID = content
 		case TagIgnore:
@@ -53,6 +61,9 @@ func (p *Processor) parseOperation(pkg *packages.Package, file *ast.File, fn *as
 			op.Description += content
 		case TagTags:
 			op.Tags = parseTags(content)
+			for _, t := range op.Tags {
+				p.tagRefs = append(p.tagRefs, annotationRef{name: t, pos: p.pos})
+			}
 
 		// ========== 请求控制 ==========
 		case TagParam:
@@ -77,6 +88,8 @@ func (p *Processor) parseOperation(pkg *packages.Package, file *ast.File, fn *as
 				if len(parts) > 1 {
 					op.ExternalDocs.Description = strings.Join(parts[1:], " ")
 				}
+			} else {
+				p.warnf("@ExternalDocs 缺少 url，已忽略")
 			}
 
 		// ========== 安全 ==========
@@ -87,22 +100,66 @@ func (p *Processor) parseOperation(pkg *packages.Package, file *ast.File, fn *as
 					params[0]: params[1:],
 				}
 				op.Security = append(op.Security, req)
+				p.securityRefs = append(p.securityRefs, annotationRef{name: params[0], pos: p.pos})
+			} else {
+				p.warnf("@Security 缺少安全方案名称，已忽略")
+			}
+
+		default:
+			p.warnf("未知的接口注解 %s", tag)
+		}
+	}
+
+	// @Router 优先，没有时使用路由发现的结果
+	routes := annotated
+	if len(annotated) > 0 {
+		registered := p.handlerRoutes(pkg, fn)
+		for _, r := range annotated {
+			p.checkRouterAnnotation(r, registered)
+		}
+	} else {
+		routes = p.handlerRoutes(pkg, fn)
+		for _, r := range routes {
+			if r.anyMethod {
+				p.report(r.pos, SeverityWarning, fmt.Sprintf(
+					"路由 %s 没有指定 HTTP 方法，匹配所有方法，文档中只按 [%s] 记录 (可在注册时写明方法或使用 @Router 声明)", r.path, r.method))
 			}
 		}
 	}
 
 	// 跳过标记为忽略或没有路由的函数
-	if ignore || routerPath == "" {
+	if ignore || len(routes) == 0 {
 		return
 	}
 
+	// 从处理函数体推断注解中缺少的参数和请求体
+	if p.opts.InferParams {
+		p.inferRequest(pkg, fn, op, routes)
+	}
+
 	// 设置默认 OperationID
+	explicitID := op.OperationID != ""
 	if op.OperationID == "" {
 		op.OperationID = fn.Name.Name
 	}
 
 	// 添加到 Paths
-	p.addOperation(routerPath, routerMethod, op)
+	// 同一个处理函数有多个路由 (多行 @Router 或注册到多个路由) 时，每个路由使用独立的 OperationID，
+	// 并且只保留出现在自身路径模板中的 path 参数
+	for _, r := range routes {
+		routeOp := op
+		if len(routes) > 1 {
+			clone := *op
+			clone.OperationID = routeOperationID(op.OperationID, r.method, r.path)
+			clone.Parameters = routeParams(op.Parameters, r.path, routes)
+			routeOp = &clone
+		}
+		p.pos = r.pos
+		if p.addOperation(r.path, r.method, routeOp) {
+			p.claimOperationID(routeOp, r.pos, p.operationIDPrefix(pkg.Name, routeOp), explicitID)
+			p.checkPathParams(routeOp, r.path)
+		}
+	}
 }
 
 // parseTags 解析标签列表
@@ -126,6 +183,7 @@ func parseTags(content string) []string {
 func (p *Processor) parseParam(pkg *packages.Package, op *model.Operation, content string) {
 	params := splitParams(content)
 	if len(params) < 4 {
+		p.warnf("@Param 格式错误，应为 <name> <in> <type> <required> [description]: %q", content)
 		return
 	}
 
@@ -134,33 +192,61 @@ func (p *Processor) parseParam(pkg *packages.Package, op *model.Operation, conte
 	typeName := params[2]
 	required := strings.ToLower(params[3]) == "true"
 
-	var desc string
-	if len(params) > 4 {
-		desc = strings.Join(params[4:], " ")
+	if req := strings.ToLower(params[3]); req != "true" && req != "false" {
+		p.warnf("@Param %s 的 required 应为 true 或 false，实际为 %q", name, params[3])
 	}
 
+	// 描述之后的属性: enums(asc,desc) default(asc)
+	desc, attrs := splitParamAttributes(params[4:])
+
 	// 处理 body 参数
 	if in == "body" {
+		if len(attrs) > 0 {
+			p.warnf("@Param %s: body 参数不支持属性，已忽略", name)
+		}
 		p.parseBodyParam(pkg, op, typeName, desc, required)
 		return
 	}
 
 	// 处理 formData 参数
 	if in == "formdata" {
-		p.parseFormDataParam(pkg, op, name, typeName, desc, required)
+		p.parseFormDataParam(pkg, op, name, typeName, desc, required, attrs)
 		return
 	}
 
+	switch in {
+	case "path", "query", "header", "cookie":
+	default:
+		p.warnf("@Param %s 的位置 %q 未知，可选值: path, query, header, cookie, body, formData", name, params[1])
+	}
+
+	// 结构体展开为多个参数: @Param filter query model.UserFilter
+	if tagKeys, ok := paramTags[in]; ok {
+		if t := p.lookupType(pkg, typeName); t != nil && p.expandableStruct(t) {
+			if len(attrs) > 0 {
+				p.warnf("@Param %s: 展开为多个参数的结构体不支持属性，已忽略", name)
+			}
+			for _, param := range p.structParams(t, in, tagKeys) {
+				// 单独声明的同名参数优先
+				if !hasParam(op, param.Name, param.In) {
+					op.Parameters = append(op.Parameters, param)
+				}
+			}
+			return
+		}
+	}
+
 	// 处理普通参数 (path, query, header, cookie)
 	param := &model.Parameter{
 		Name:        name,
 		In:          in,
 		Description: desc,
 		Required:    required || in == "path", // path 参数始终必填
-		Schema:      p.primitiveTypeToSchema(typeName),
+		Schema:      p.paramTypeToSchema(typeName),
 	}
+	p.applyParamAttributes(name, param, param.Schema, attrs)
 
-	op.Parameters = append(op.Parameters, param)
+	setParam(op, param)
 }
 
 // parseBodyParam 解析 body 参数
@@ -188,7 +274,7 @@ func (p *Processor) parseBodyParam(pkg *packages.Package, op *model.Operation, t
 }
 
 // parseFormDataParam 解析 formData 参数
-func (p *Processor) parseFormDataParam(pkg *packages.Package, op *model.Operation, name, typeName, desc string, required bool) {
+func (p *Processor) parseFormDataParam(pkg *packages.Package, op *model.Operation, name, typeName, desc string, required bool, attrs []paramAttribute) {
 	// 确保 RequestBody 存在
 	if op.RequestBody == nil {
 		op.RequestBody = &model.RequestBody{
@@ -212,7 +298,7 @@ func (p *Processor) parseFormDataParam(pkg *packages.Package, op *model.Operatio
 		mediaType = &model.MediaType{
 			Schema: &model.Schema{
 				Type:       "object",
-				Properties: make(map[string]*model.Schema),
+				Properties: model.NewOrderedMap[*model.Schema](),
 			},
 		}
 		op.RequestBody.Content[contentType] = mediaType
@@ -226,11 +312,12 @@ func (p *Processor) parseFormDataParam(pkg *packages.Package, op *model.Operatio
 			Format: "binary",
 		}
 	} else {
-		propSchema = p.primitiveTypeToSchema(typeName)
+		propSchema = p.paramTypeToSchema(typeName)
 	}
 	propSchema.Description = desc
+	p.applyParamAttributes(name, nil, propSchema, attrs)
 
-	mediaType.Schema.Properties[name] = propSchema
+	mediaType.Schema.Properties.Set(name, propSchema)
 
 	if required {
 		mediaType.Schema.Required = append(mediaType.Schema.Required, name)
@@ -242,8 +329,12 @@ func (p *Processor) parseFormDataParam(pkg *packages.Package, op *model.Operatio
 func (p *Processor) parseResponse(pkg *packages.Package, op *model.Operation, content string) {
 	status, dataType, dataModel, desc := parseResponseType(content)
 	if status == "" {
+		p.warnf("响应注解缺少状态码: %q", content)
 		return
 	}
+	if !isValidStatusCode(status) {
+		p.warnf("响应状态码 %q 无效，应为 100-599、1XX-5XX 或 default", status)
+	}
 
 	var schema *model.Schema
 
@@ -258,11 +349,19 @@ func (p *Processor) parseResponse(pkg *packages.Package, op *model.Operation, co
 		}
 	case "string", "integer", "number", "boolean":
 		schema = &model.Schema{Type: dataType}
-	default:
+	case "":
 		// 如果没有指定类型，尝试解析 dataModel
 		if dataModel != "" {
 			schema = p.resolveTypeSchema(pkg, dataModel)
 		}
+	default:
+		p.warnf("响应类型 {%s} 未知，可选值: object, array, string, integer, number, boolean", dataType)
+		if dataModel != "" {
+			schema = p.resolveTypeSchema(pkg, dataModel)
+		}
+	}
+	if (dataType == "object" || dataType == "array") && dataModel == "" {
+		p.warnf("响应类型 {%s} 缺少数据类型: %q", dataType, content)
 	}
 
 	// 确定 content-type
@@ -297,6 +396,7 @@ func (p *Processor) parseResponse(pkg *packages.Package, op *model.Operation, co
 func (p *Processor) parseResponseHeader(op *model.Operation, content string) {
 	params := splitParams(content)
 	if len(params) < 3 {
+		p.warnf("@Header 格式错误，应为 <status> {<type>} <name> [description]: %q", content)
 		return
 	}
 
@@ -319,9 +419,14 @@ func (p *Processor) parseResponseHeader(op *model.Operation, content string) {
 		}
 	}
 
+	if headerName == "" {
+		p.warnf("@Header 缺少响应头名称: %q", content)
+		return
+	}
+
 	header := &model.Header{
 		Description: desc,
-		Schema:      p.primitiveTypeToSchema(headerType),
+		Schema:      p.paramTypeToSchema(headerType),
 	}
 
 	// 找到对应的响应并添加 Header
@@ -332,28 +437,64 @@ func (p *Processor) parseResponseHeader(op *model.Operation, content string) {
 		resp = op.Responses.Codes[status]
 	}
 
-	if resp != nil {
-		if resp.Headers == nil {
-			resp.Headers = make(map[string]*model.Header)
-		}
-		resp.Headers[headerName] = header
+	if resp == nil {
+		p.warnf("@Header %s 引用的状态码 %s 没有对应的 @Success/@Failure 声明 (需写在其之后)", headerName, status)
+		return
+	}
+
+	if resp.Headers == nil {
+		resp.Headers = make(map[string]*model.Header)
+	}
+	resp.Headers[headerName] = header
+}
+
+// isValidStatusCode 检查响应状态码是否合法
+// 支持: default, 具体状态码 (200), 范围状态码 (2XX)
+func isValidStatusCode(status string) bool {
+	if status == "default" {
+		return true
+	}
+	if len(status) != 3 || status[0] < '1' || status[0] > '5' {
+		return false
+	}
+	rest := strings.ToUpper(status[1:])
+	if rest == "XX" {
+		return true
 	}
+	return rest[0] >= '0' && rest[0] <= '9' && rest[1] >= '0' && rest[1] <= '9'
 }
 
-// addOperation 添加操作到 Paths
-func (p *Processor) addOperation(path, method string, op *model.Operation) {
+// paramTypeToSchema 将参数类型名转换为 Schema，无法识别时记录警告
+func (p *Processor) paramTypeToSchema(typeName string) *model.Schema {
+	schema := p.primitiveTypeToSchema(typeName)
+	if schema.Type == nil {
+		p.warnf("参数类型 %q 不是基本类型，已退化为无约束 Schema", typeName)
+	}
+	return schema
+}
+
+// addOperation 添加操作到 Paths，路由已被其他接口定义时忽略并返回 false
+func (p *Processor) addOperation(path, method string, op *model.Operation) bool {
+	if !isHTTPToken(method) {
+		p.warnf("无效的 HTTP 方法 [%s]，接口 %s 已忽略", method, path)
+		return false
+	}
+	if !p.claimRoute(method, path, p.pos) {
+		return false
+	}
+
 	// 确保 Paths 存在
 	if p.OpenAPI.Paths == nil {
 		p.OpenAPI.Paths = &model.Paths{
-			Paths: make(map[string]*model.PathItem),
+			Paths: model.NewOrderedMap[*model.PathItem](),
 		}
 	}
 
 	// 确保 PathItem 存在
-	pathItem, ok := p.OpenAPI.Paths.Paths[path]
+	pathItem, ok := p.OpenAPI.Paths.Paths.Get(path)
 	if !ok {
 		pathItem = &model.PathItem{}
-		p.OpenAPI.Paths.Paths[path] = pathItem
+		p.OpenAPI.Paths.Paths.Set(path, pathItem)
 	}
 
 	// 根据方法设置操作
@@ -376,5 +517,46 @@ func (p *Processor) addOperation(path, method string, op *model.Operation) {
 		pathItem.Trace = op
 	case "query":
 		pathItem.Query = op
+	default:
+		// 其他方法 (如 COPY、PURGE) 放入 OpenAPI 3.2 的 additionalOperations，Key 为大写的方法名
+		// 未在 IANA 注册且未通过 CustomMethods 声明的方法多半是拼写错误，给出警告
+		upper := strings.ToUpper(method)
+		if !registeredMethods[upper] && !slices.ContainsFunc(p.opts.CustomMethods, func(m string) bool {
+			return strings.EqualFold(m, upper)
+		}) {
+			p.warnf("非标准的 HTTP 方法 [%s] (接口 %s)，已输出到 additionalOperations，自定义方法需通过 CustomMethods 声明", method, path)
+		}
+		if pathItem.AdditionalOperations == nil {
+			pathItem.AdditionalOperations = make(map[string]*model.Operation)
+		}
+		pathItem.AdditionalOperations[upper] = op
+		p.additionalOps = append(p.additionalOps, annotationRef{name: upper, pos: p.pos})
+	}
+	return true
+}
+
+// registeredMethods IANA HTTP Method Registry 中没有对应 PathItem 字段的方法
+var registeredMethods = map[string]bool{
+	"ACL": true, "BASELINE-CONTROL": true, "BIND": true, "CHECKIN": true, "CHECKOUT": true,
+	"CONNECT": true, "COPY": true, "LABEL": true, "LINK": true, "LOCK": true,
+	"MERGE": true, "MKACTIVITY": true, "MKCALENDAR": true, "MKCOL": true, "MKREDIRECTREF": true,
+	"MKWORKSPACE": true, "MOVE": true, "ORDERPATCH": true, "PROPFIND": true, "PROPPATCH": true,
+	"REBIND": true, "REPORT": true, "SEARCH": true, "UNBIND": true, "UNCHECKOUT": true,
+	"UNLINK": true, "UNLOCK": true, "UPDATE": true, "UPDATEREDIRECTREF": true, "VERSION-CONTROL": true,
+}
+
+// isHTTPToken 检查方法名是否符合 HTTP token 语法 (RFC 9110 5.6.2)
+func isHTTPToken(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", c):
+		default:
+			return false
+		}
 	}
+	return true
 }
@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIsHTTPToken(t *testing.T) {
+	tests := []struct {
+		method string
+		want   bool
+	}{
+		{"get", true},
+		{"PURGE", true},
+		{"M-SEARCH", true},
+		{"x_custom~1", true},
+		{"", false},
+		{"ge(t", false},
+		{"get post", false},
+		{"get,post", false},
+		{"获取", false},
+	}
+
+	for _, tt := range tests {
+		if got := isHTTPToken(tt.method); got != tt.want {
+			t.Errorf("isHTTPToken(%q) = %v, want %v", tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestAdditionalOperations(t *testing.T) {
+	openapi, diags := parseApp(t, Options{CustomMethods: []string{"PURGE"}})
+
+	tests := []struct {
+		name   string
+		path   string
+		method string
+		found  bool
+		warn   string
+	}{
+		{"拼写错误的方法", "/typo", "gettt", true, "非标准的 HTTP 方法 [gettt]"},
+		{"不符合 token 语法的方法", "/invalid", "ge(t", false, "无效的 HTTP 方法 [ge(t]"},
+		{"CustomMethods 声明的方法", "/cache", "purge", true, ""},
+		{"IANA 登记的方法", "/cache", "copy", true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if op := findOperation(openapi, tt.path, tt.method); (op != nil) != tt.found {
+				t.Errorf("[%s] %s 存在 = %v, want %v", tt.method, tt.path, op != nil, tt.found)
+			}
+			if tt.warn != "" && !hasDiagnostic(diags, tt.warn) {
+				t.Errorf("缺少警告 %q", tt.warn)
+			}
+		})
+	}
+
+	for _, method := range []string{"purge", "copy"} {
+		if hasDiagnostic(diags, "非标准的 HTTP 方法 ["+method+"]") {
+			t.Errorf("方法 %s 不应报告为非标准方法", method)
+		}
+	}
+	// 多条 @Router 时 operationId 追加方法与路径
+	if op := findOperation(openapi, "/cache", "purge"); op != nil && op.OperationID != "Purge_purge_cache" {
+		t.Errorf("operationId = %q, want %q", op.OperationID, "Purge_purge_cache")
+	}
+	// 3.2.0 支持 additionalOperations
+	if hasDiagnostic(diags, "需要 OpenAPI 3.2 及以上版本") {
+		t.Errorf("OpenAPI 3.2.0 不应报告 additionalOperations 版本警告")
+	}
+}
+
+func TestMultiRouterParams(t *testing.T) {
+	openapi, _ := parseApp(t, Options{})
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/things/{id}", []string{"path:id", "query:q"}},
+		{"/v3/things", []string{"query:q"}},
+	}
+
+	for _, tt := range tests {
+		op := findOperation(openapi, tt.path, "get")
+		if op == nil {
+			t.Fatalf("未找到接口 %s", tt.path)
+		}
+		if got := paramKeys(op); !slices.Equal(got, tt.want) {
+			t.Errorf("%s 的参数 = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package parser
+
+import (
+	"go/types"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+// paramTags @Param 展开结构体时各位置的参数名 tag，按顺序使用第一个存在的 tag
+var paramTags = map[string][]string{
+	"query":  {"query", "form"},
+	"path":   {"uri", "path"},
+	"header": {"header"},
+	"cookie": {"cookie"},
+}
+
+// structParams 将结构体字段展开为 in 位置的参数 (如 @Param filter query model.UserFilter)
+// 参数名来自 tagKeys 中第一个存在的 tag，没有 tag 的字段使用字段名，与 gin 的绑定规则一致
+// 支持 tag 中的默认值: form:"page,default=1"
+func (p *Processor) structParams(t types.Type, in string, tagKeys []string) []*model.Parameter {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	var params []*model.Parameter
+	visited := make(map[*types.Struct]bool)
+
+	var walk func(st *types.Struct)
+	walk = func(st *types.Struct) {
+		// 防止嵌入自身 (如 type Loop struct{ *Loop }) 导致死循环
+		if visited[st] {
+			return
+		}
+		visited[st] = true
+		defer delete(visited, st)
+
+		for i := 0; i < st.NumFields(); i++ {
+			field := st.Field(i)
+			tag := st.Tag(i)
+
+			var name, opts string
+			for _, key := range tagKeys {
+				if value := lookupTag(tag, key); value != "" {
+					name, opts, _ = strings.Cut(value, ",")
+					break
+				}
+			}
+			if name == "-" {
+				continue
+			}
+
+			// 没有 tag 的嵌入结构体: 展开其字段
+			if field.Embedded() && name == "" {
+				if inner, ok := embeddedStruct(field.Type()); ok {
+					walk(inner)
+					continue
+				}
+			}
+			if !field.Exported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name()
+			}
+			params = append(params, p.fieldParam(field, tag, name, opts, in))
+		}
+	}
+	walk(st)
+	return params
+}
+
+// fieldParam 将结构体字段转换为 in 位置的参数
+func (p *Processor) fieldParam(field *types.Var, tag, name, opts, in string) *model.Parameter {
+	schema := p.typeToSchema(field.Type())
+	required := applyValidation(schema, field.Type(), tag)
+	for _, opt := range strings.Split(opts, ",") {
+		if value, ok := strings.CutPrefix(opt, "default="); ok {
+			schema.Default = typedValue(schema, value)
+		}
+	}
+
+	param := &model.Parameter{
+		Name:     name,
+		In:       in,
+		Required: required || in == "path", // path 参数始终必填
+		Schema:   schema,
+	}
+	// 数组参数以 ?tags=a&tags=b 的形式传递
+	if schema.Type == "array" && (in == "query" || in == "cookie") {
+		param.Style = "form"
+		param.Explode = ptrTo(true)
+	}
+	if desc := parseDescTag(tag); desc != "" {
+		param.Description = desc
+	} else {
+		param.Description = p.namedDoc(field.Pos(), field.Name())
+	}
+	return param
+}
+
+// paramStyles 参数支持的序列化方式
+var paramStyles = map[string]bool{
+	"matrix": true, "label": true, "simple": true, "form": true,
+	"spaceDelimited": true, "pipeDelimited": true, "deepObject": true, "cookie": true,
+}
+
+// applyParamAttributes 将 @Param 的属性应用到参数及其 Schema
+// param 为 nil 时 (formData 字段) 只应用 Schema 相关的属性
+func (p *Processor) applyParamAttributes(name string, param *model.Parameter, schema *model.Schema, attrs []paramAttribute) {
+	for _, attr := range attrs {
+		switch attr.name {
+		case "enums", "enum":
+			schema.Enum = nil
+			for _, v := range strings.Split(attr.value, ",") {
+				schema.Enum = append(schema.Enum, typedValue(schema, strings.TrimSpace(v)))
+			}
+		case "default":
+			schema.Default = typedValue(schema, attr.value)
+		case "example":
+			if param != nil {
+				param.Example = typedValue(schema, attr.value)
+			} else {
+				schema.Examples = append(schema.Examples, typedValue(schema, attr.value))
+			}
+		case "minimum", "maximum":
+			value := parseNumber(attr.value)
+			if _, ok := value.(string); ok {
+				p.warnf("@Param %s 的 %s 应为数字，实际为 %q", name, attr.name, attr.value)
+				continue
+			}
+			if attr.name == "minimum" {
+				schema.Minimum = value
+			} else {
+				schema.Maximum = value
+			}
+		case "minlength", "maxlength":
+			n, err := strconv.Atoi(attr.value)
+			if err != nil || n < 0 {
+				p.warnf("@Param %s 的 %s 应为非负整数，实际为 %q", name, attr.name, attr.value)
+				continue
+			}
+			if attr.name == "minlength" {
+				schema.MinLength = n
+			} else {
+				schema.MaxLength = n
+			}
+		case "pattern":
+			schema.Pattern = attr.value
+		case "format":
+			schema.Format = attr.value
+		default:
+			// 参数自身的属性
+			if param == nil {
+				p.warnf("@Param %s 的 %s 只适用于 path、query、header、cookie 参数，已忽略", name, attr.name)
+				continue
+			}
+			p.applyParamFlag(param, attr)
+		}
+	}
+}
+
+// applyParamFlag 应用参数自身的属性: deprecated、style、explode、allowEmptyValue
+func (p *Processor) applyParamFlag(param *model.Parameter, attr paramAttribute) {
+	if attr.name == "style" {
+		if !paramStyles[attr.value] {
+			p.warnf("@Param %s 的 style %q 未知，可选值: matrix, label, simple, form, spaceDelimited, pipeDelimited, deepObject, cookie", param.Name, attr.value)
+			return
+		}
+		param.Style = attr.value
+		return
+	}
+
+	value, err := strconv.ParseBool(attr.value)
+	if err != nil {
+		p.warnf("@Param %s 的 %s 应为 true 或 false，实际为 %q", param.Name, attr.name, attr.value)
+		return
+	}
+	switch attr.name {
+	case "deprecated":
+		param.Deprecated = value
+	case "explode":
+		param.Explode = &value
+	case "allowemptyvalue":
+		param.AllowEmptyValue = value
+	}
+}
+
+// typedValue 按 Schema 类型转换字符串形式的值 (默认值、枚举值、示例)
+func typedValue(schema *model.Schema, value string) any {
+	switch schema.Type {
+	case "integer", "number":
+		return parseNumber(value)
+	case "boolean":
+		return value == "true"
+	default:
+		return value
+	}
+}
+
+// ptrTo 返回指向 v 的指针
+func ptrTo[T any](v T) *T {
+	return &v
+}
+
+// setParam 添加参数，已有同名同位置的参数时替换
+func setParam(op *model.Operation, param *model.Parameter) {
+	for i, existing := range op.Parameters {
+		if existing.Name == param.Name && existing.In == param.In {
+			op.Parameters[i] = param
+			return
+		}
+	}
+	op.Parameters = append(op.Parameters, param)
+}
+
+// hasParam 检查操作中是否已有同名同位置的参数
+func hasParam(op *model.Operation, name, in string) bool {
+	for _, param := range op.Parameters {
+		if param.Name == name && param.In == in {
+			return true
+		}
+	}
+	return false
+}
+
+// expandableStruct 检查类型是否是可以展开为参数的结构体
+// time.Time 等自身声明了 JSON 表现的结构体作为单个参数处理
+func (p *Processor) expandableStruct(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if _, ok := t.Underlying().(*types.Struct); !ok {
+		return false
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	return !ok || p.declaredTypeSchema(named) == nil
+}
+
+// 路径模板中的参数名: /users/{id} -> id
+var pathTemplatePattern = regexp.MustCompile(`\{([^}]*)\}`)
+
+// pathTemplateNames 路径模板中的参数名
+func pathTemplateNames(path string) map[string]bool {
+	names := make(map[string]bool)
+	for _, m := range pathTemplatePattern.FindAllStringSubmatch(path, -1) {
+		names[m[1]] = true
+	}
+	return names
+}
+
+// routeParams 多个路由共用一组参数时，去掉不在 path 模板中、但属于其他路由模板的 path 参数
+// 如 @Router /things/{id} 与 @Router /v3/things 共用 @Param id path，/v3/things 不应包含 id
+// 不在任何路由模板中的 path 参数保留，由 checkPathParams 报告
+func routeParams(params []*model.Parameter, path string, routes []route) []*model.Parameter {
+	own := pathTemplateNames(path)
+	others := make(map[string]bool)
+	for _, r := range routes {
+		for name := range pathTemplateNames(r.path) {
+			others[name] = true
+		}
+	}
+
+	var kept []*model.Parameter
+	for _, param := range params {
+		if param.In == "path" && !own[param.Name] && others[param.Name] {
+			continue
+		}
+		kept = append(kept, param)
+	}
+	return kept
+}
+
+// checkPathParams 检查路径模板中的参数与 path 参数是否一一对应 (OpenAPI 要求每个模板参数都有对应的 path 参数)
+// Options.AutoPathParams 开启时为缺少声明的模板参数补充 string 类型的 path 参数
+func (p *Processor) checkPathParams(op *model.Operation, path string) {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range pathTemplatePattern.FindAllStringSubmatch(path, -1) {
+		name := m[1]
+		if seen[name] {
+			p.warnf("路径 %s 中的参数 {%s} 重复出现", path, name)
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	for _, param := range op.Parameters {
+		if param.In == "path" && !seen[param.Name] {
+			p.warnf("path 参数 %s 不在路径 %s 中", param.Name, path)
+		}
+	}
+
+	var missing []*model.Parameter
+	for _, name := range names {
+		if hasParam(op, name, "path") {
+			continue
+		}
+		if !p.opts.AutoPathParams {
+			p.warnf("路径 %s 中的参数 {%s} 缺少 @Param %s path 声明", path, name, name)
+			continue
+		}
+		missing = append(missing, &model.Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &model.Schema{Type: "string"},
+		})
+	}
+	if len(missing) > 0 {
+		// 多个路由共用同一组参数时不能修改原切片
+		op.Parameters = append(missing, op.Parameters...)
+	}
+}
@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+func TestStructParams(t *testing.T) {
+	openapi, _ := parseApp(t, Options{})
+
+	op := findOperation(openapi, "/models/filtered", "get")
+	if op == nil {
+		t.Fatal("未找到接口 /models/filtered")
+	}
+	// 单独声明的 size 优先于结构体展开的同名参数，嵌入结构体的字段被提升
+	want := []string{"query:page", "query:keyword", "query:tags", "query:size"}
+	if got := paramKeys(op); !slices.Equal(got, want) {
+		t.Fatalf("参数 = %v, want %v", got, want)
+	}
+
+	page, keyword, tags, size := op.Parameters[0], op.Parameters[1], op.Parameters[2], op.Parameters[3]
+	if fmt.Sprint(page.Schema.Default) != "1" {
+		t.Errorf("page 的默认值 = %v, want 1", page.Schema.Default)
+	}
+	if !keyword.Required || page.Required {
+		t.Errorf("required: keyword = %v, page = %v, want true, false", keyword.Required, page.Required)
+	}
+	if keyword.Description != "搜索关键字" {
+		t.Errorf("keyword 的描述 = %q, want %q", keyword.Description, "搜索关键字")
+	}
+	if tags.Style != "form" || tags.Explode == nil || !*tags.Explode {
+		t.Errorf("tags 的 style/explode = %q/%v, want form/true", tags.Style, tags.Explode)
+	}
+	if size.Description != "page size" {
+		t.Errorf("size 的描述 = %q, want 单独声明的 %q", size.Description, "page size")
+	}
+}
+
+func TestStructParamsSelfEmbed(t *testing.T) {
+	openapi, _ := parseApp(t, Options{})
+
+	// Loop 嵌入 *Loop，展开时不应无限递归
+	op := findOperation(openapi, "/models/loop", "get")
+	if op == nil {
+		t.Fatal("未找到接口 /models/loop")
+	}
+	if got := paramKeys(op); !slices.Equal(got, []string{"query:q"}) {
+		t.Errorf("参数 = %v, want [query:q]", got)
+	}
+}
+
+func TestParamAttributes(t *testing.T) {
+	openapi, diags := parseApp(t, Options{})
+
+	op := findOperation(openapi, "/models/sorted", "get")
+	if op == nil {
+		t.Fatal("未找到接口 /models/sorted")
+	}
+	want := []string{"query:sort", "query:limit", "query:ids", "header:old", "query:bad"}
+	if got := paramKeys(op); !slices.Equal(got, want) {
+		t.Fatalf("参数 = %v, want %v", got, want)
+	}
+
+	sort, limit, ids, old := op.Parameters[0], op.Parameters[1], op.Parameters[2], op.Parameters[3]
+	if sort.Description != "sort order" {
+		t.Errorf("sort 的描述 = %q, want 去掉属性后的 %q", sort.Description, "sort order")
+	}
+	if fmt.Sprint(sort.Schema.Enum) != "[asc desc]" || sort.Schema.Default != "asc" {
+		t.Errorf("sort 的 enum/default = %v/%v, want [asc desc]/asc", sort.Schema.Enum, sort.Schema.Default)
+	}
+	// 数值按参数类型转换
+	if fmt.Sprint(limit.Schema.Minimum, limit.Schema.Maximum, limit.Example) != "1 100 20" {
+		t.Errorf("limit 的 minimum/maximum/example = %v/%v/%v, want 1/100/20", limit.Schema.Minimum, limit.Schema.Maximum, limit.Example)
+	}
+	if _, ok := limit.Example.(string); ok {
+		t.Errorf("limit 的 example = %#v, want 数字", limit.Example)
+	}
+	if ids.Style != "pipeDelimited" || ids.Explode == nil || *ids.Explode {
+		t.Errorf("ids 的 style/explode = %q/%v, want pipeDelimited/false", ids.Style, ids.Explode)
+	}
+	if !old.Deprecated {
+		t.Error("old 应标记为 deprecated")
+	}
+
+	for _, msg := range []string{`@Param bad 的 minimum 应为数字，实际为 "abc"`, `@Param bad 的 style "zigzag" 未知`} {
+		if !hasDiagnostic(diags, msg) {
+			t.Errorf("缺少警告 %q", msg)
+		}
+	}
+}
+
+func TestRouteParams(t *testing.T) {
+	params := []*model.Parameter{
+		{Name: "id", In: "path"},
+		{Name: "oid", In: "path"},
+		{Name: "missing", In: "path"},
+		{Name: "id", In: "query"},
+	}
+	routes := []route{{path: "/users/{id}"}, {path: "/users/{id}/orders/{oid}"}, {path: "/orders"}}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/users/{id}/orders/{oid}", []string{"path:id", "path:oid", "path:missing", "query:id"}},
+		// 属于其他路由模板的 path 参数去掉，不在任何模板中的保留给 checkPathParams 报告
+		{"/users/{id}", []string{"path:id", "path:missing", "query:id"}},
+		{"/orders", []string{"path:missing", "query:id"}},
+	}
+
+	for _, tt := range tests {
+		op := &model.Operation{Parameters: routeParams(params, tt.path, routes)}
+		if got := paramKeys(op); !slices.Equal(got, tt.want) {
+			t.Errorf("routeParams(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestCheckPathParams(t *testing.T) {
+	t.Run("报告不一致", func(t *testing.T) {
+		openapi, diags := parseApp(t, Options{})
+
+		for _, msg := range []string{
+			"路径 /pathcheck/users/{id} 中的参数 {id} 缺少 @Param id path 声明",
+			"path 参数 oid 不在路径 /pathcheck/orders 中",
+			"路径 /pathcheck/{id}/copy/{id} 中的参数 {id} 重复出现",
+		} {
+			if !hasDiagnostic(diags, msg) {
+				t.Errorf("缺少警告 %q", msg)
+			}
+		}
+		op := findOperation(openapi, "/pathcheck/users/{id}", "get")
+		if op == nil {
+			t.Fatal("未找到接口 /pathcheck/users/{id}")
+		}
+		if got := paramKeys(op); !slices.Equal(got, []string{"query:q"}) {
+			t.Errorf("未开启 AutoPathParams 时参数 = %v, want [query:q]", got)
+		}
+	})
+
+	t.Run("AutoPathParams", func(t *testing.T) {
+		openapi, diags := parseApp(t, Options{AutoPathParams: true})
+
+		if hasDiagnostic(diags, "缺少 @Param id path 声明") {
+			t.Error("开启 AutoPathParams 后不应报告缺少的 path 参数")
+		}
+		op := findOperation(openapi, "/pathcheck/users/{id}", "get")
+		if op == nil {
+			t.Fatal("未找到接口 /pathcheck/users/{id}")
+		}
+		if got := paramKeys(op); !slices.Equal(got, []string{"path:id", "query:q"}) {
+			t.Fatalf("参数 = %v, want [path:id query:q]", got)
+		}
+		if id := op.Parameters[0]; !id.Required || id.Schema.Type != "string" {
+			t.Errorf("补全的 id: required = %v, type = %q, want true, string", id.Required, id.Schema.Type)
+		}
+	})
+}
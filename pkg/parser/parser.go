@@ -2,16 +2,80 @@ package parser
 
 import (
 	"fmt"
+	"go/token"
 
 	"github.com/promonkeyli/goas/pkg/model"
 	"golang.org/x/tools/go/packages"
 )
 
-func Parse(dirs []string) (*model.T, error) {
+// Options 解析选项
+type Options struct {
+	// EmbedAllOf 嵌入结构体不展开字段，而是以 allOf: [{$ref: Base}, {...}] 的形式复用基础模型
+	EmbedAllOf bool
+	// TypeOverrides 自定义类型映射，Key 为全限定类型名 (包路径.类型名)，如 "github.com/shopspring/decimal.Decimal"
+	// 命中的类型直接使用配置的 Schema，优先于内置的常见类型映射
+	TypeOverrides map[string]*model.Schema
+	// Naming Components 中 Schema 的命名策略: NamingShort (默认)、NamingPackage、NamingFull
+	Naming string
+	// GenericNaming 泛型实例的内置命名方式: GenericNamingOf (默认)、GenericNamingUnderscore、GenericNamingConcat、GenericNamingGuillemet
+	GenericNaming string
+	// GenericNamer 自定义的泛型实例命名函数，优先于 GenericNaming
+	GenericNamer GenericNamer
+	// Required 必填字段的判定方式: RequiredDefault (默认)、RequiredOmitEmpty、RequiredValidate
+	Required string
+	// Nullable 可为 null 的字段: NullablePointer (默认)、NullableNone
+	Nullable string
+	// DiscoverRoutes 从路由注册代码 (gin、chi、echo、net/http ServeMux) 中发现路由，
+	// 没有 @Router 注解的处理函数使用发现的路由
+	DiscoverRoutes bool
+	// RouteExtractors 自定义的路由提取器 (如内部框架)，优先于内置的提取器，仅在 DiscoverRoutes 开启时生效
+	RouteExtractors []RouteExtractor
+	// InferParams 从处理函数体中的 gin 调用 (c.ShouldBindJSON(&req)、c.Param("id")、c.Query("page") 等)
+	// 推断注解中缺少的参数和请求体，显式的 @Param 始终优先
+	InferParams bool
+	// OperationIDConflict 重复 OperationID 的处理方式: OperationIDReport (默认)、OperationIDPackage、OperationIDTag
+	OperationIDConflict string
+	// AutoPathParams 为路径模板中缺少 @Param 声明的参数自动生成 string 类型的必填 path 参数
+	AutoPathParams bool
+	// GlobalPackage 全局注解所在的包路径 (如 "github.com/acme/shop/api")
+	// 多个包 (如多个 main 包) 都有全局注解时以该包为准，为空时依次选择 @GoasGlobal、main 函数、带全局注解的 package 注释所在的第一个包
+	GlobalPackage string
+	// Packages 只收录这些包中的接口，为空时收录所有扫描的包
+	// 支持完整包路径 ("github.com/acme/shop/api") 与前缀匹配 ("github.com/acme/shop/internal/...")
+	Packages []string
+	// IncludeTags 只收录带有其中任一标签的接口，为空时不限制
+	IncludeTags []string
+	// ExcludeTags 不收录带有其中任一标签的接口，这些标签也不会出现在全局标签列表中
+	ExcludeTags []string
+	// CustomMethods 允许的自定义 HTTP 方法 (如 PURGE)，未在 IANA 注册且不在该列表中的方法会给出警告
+	CustomMethods []string
+}
+
+// builtinRouteExtractors 内置的路由提取器
+var builtinRouteExtractors = []RouteExtractor{ginExtractor{}, chiExtractor{}, echoExtractor{}, serveMuxExtractor{}}
+
+// Parse 扫描目录并解析注释，返回 OpenAPI 文档以及解析过程中发现的诊断信息
+func Parse(dirs []string, opts Options) (*model.T, Diagnostics, error) {
+	prog, err := Load(dirs)
+	if err != nil {
+		return nil, nil, err
+	}
+	openapi, diags := prog.Parse(opts)
+	return openapi, diags, nil
+}
+
+// Program 加载完成的包，同一次加载可以按不同的选项解析出多份文档 (如按服务或受众拆分)
+type Program struct {
+	fset *token.FileSet
+	pkgs []*packages.Package
+}
+
+// Load 扫描目录并加载包及其类型信息
+func Load(dirs []string) (*Program, error) {
 	fmt.Printf("开始扫描目录: %v\n", dirs)
 
 	// 1. 初始化
-	p := newProcessor()
+	fset := token.NewFileSet()
 
 	// 1. 配置加载模式
 	// 这是 go/packages 最强大的地方，我们需要：
@@ -21,10 +85,13 @@ func Parse(dirs []string) (*model.T, error) {
 	// - NeedTypes: 类型信息 (为了解析结构体字段类型)
 	// - NeedTypesInfo: 具体的 AST 到 Type 的映射
 	// - NeedImports: 解析 import 关系
+	// - NeedModule: 包所在的 module (区分项目代码与依赖)
 	cfg := &packages.Config{
+		Fset: fset,
 		Mode: packages.NeedName | packages.NeedFiles |
 			packages.NeedSyntax | packages.NeedTypes |
-			packages.NeedTypesInfo | packages.NeedImports,
+			packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps |
+			packages.NeedModule,
 		Tests: false, // 通常不需要扫描测试文件
 	}
 
@@ -41,17 +108,43 @@ func Parse(dirs []string) (*model.T, error) {
 		return nil, fmt.Errorf("源码中存在错误，无法继续解析")
 	}
 
+	return &Program{fset: fset, pkgs: pkgs}, nil
+}
+
+// Parse 按选项解析加载的包，返回 OpenAPI 文档以及解析过程中发现的诊断信息
+// 每次调用使用独立的解析状态，可以对同一个 Program 多次调用
+func (prog *Program) Parse(opts Options) (*model.T, Diagnostics) {
+	p := newProcessor(prog.fset, opts)
+	pkgs := prog.pkgs
+
 	// 4. 【建立索引】构建包映射表
 	// 这步至关重要：把 slice 转成 map，后续查 "github.com/lib/pq" 这种路径时能 O(1) 找到
 	for _, pkg := range pkgs {
 		p.addToIndex(pkg)
+		p.addScanned(pkg)
+	}
+
+	// 同名结构体按包路径预留 Schema 名称，与解析顺序无关
+	p.reserveComponentNames()
+
+	// 5. 【路由发现】从路由注册代码中找出处理函数对应的路由
+	if opts.DiscoverRoutes {
+		extractors := append(append([]RouteExtractor{}, opts.RouteExtractors...), builtinRouteExtractors...)
+		p.discoverRoutes(pkgs, extractors)
 	}
 
-	// 5. 【执行扫描】
+	// 6. 【执行扫描】
 	// 遍历所有请求的包，寻找 Controller/API 定义
 	for _, pkg := range pkgs {
 		p.scanPackage(pkg)
 	}
 
-	return p.OpenAPI, nil
+	// 7. 【全局注释】从选定的包中解析全局注释
+	p.parseGlobals()
+	p.filterGlobalTags()
+
+	// 8. 【交叉校验】检查引用的安全方案和标签是否已声明
+	p.checkReferences()
+
+	return p.OpenAPI, p.Diagnostics
 }
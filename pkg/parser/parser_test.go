@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+// testPrograms 已加载的 testdata module，每个 module 只加载一次
+var testPrograms = make(map[string]*Program)
+
+// loadTestdata 加载 testdata 目录下的 module
+// go/packages 在当前目录执行 go list，因此需要切换到 module 所在目录
+func loadTestdata(t *testing.T, module string) *Program {
+	t.Helper()
+	if prog, ok := testPrograms[module]; ok {
+		return prog
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(filepath.Join("testdata", module)); err != nil {
+		t.Fatal(err)
+	}
+	prog, err := Load([]string{"./..."})
+	if err := os.Chdir(wd); err != nil {
+		t.Fatal(err)
+	}
+	if err != nil {
+		t.Fatalf("Load(%s) error = %v", module, err)
+	}
+
+	testPrograms[module] = prog
+	return prog
+}
+
+// parseApp 解析 testdata/app，全局注释固定使用 main 包
+func parseApp(t *testing.T, opts Options) (*model.T, Diagnostics) {
+	t.Helper()
+	if opts.GlobalPackage == "" {
+		opts.GlobalPackage = "example.com/app"
+	}
+	return loadTestdata(t, "app").Parse(opts)
+}
+
+// findOperation 查找路径上指定方法的接口，不存在时返回 nil
+func findOperation(openapi *model.T, path, method string) *model.Operation {
+	if openapi.Paths == nil {
+		return nil
+	}
+	item, ok := openapi.Paths.Paths.Get(path)
+	if !ok {
+		return nil
+	}
+	switch method {
+	case "get":
+		return item.Get
+	case "post":
+		return item.Post
+	case "put":
+		return item.Put
+	case "delete":
+		return item.Delete
+	case "patch":
+		return item.Patch
+	default:
+		return item.AdditionalOperations[strings.ToUpper(method)]
+	}
+}
+
+// paramKeys 以 "位置:名称" 的形式列出接口的参数
+func paramKeys(op *model.Operation) []string {
+	var keys []string
+	for _, param := range op.Parameters {
+		keys = append(keys, param.In+":"+param.Name)
+	}
+	return keys
+}
+
+// hasDiagnostic 检查诊断信息中是否有包含 substr 的消息
+func hasDiagnostic(diags Diagnostics, substr string) bool {
+	for _, d := range diags {
+		if strings.Contains(d.Message, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// componentSchema 获取 Components 中的 Schema
+func componentSchema(t *testing.T, openapi *model.T, name string) *model.Schema {
+	t.Helper()
+	if openapi.Components == nil || openapi.Components.Schemas[name] == nil {
+		t.Fatalf("Schema %s 不存在", name)
+	}
+	return openapi.Components.Schemas[name]
+}
@@ -2,6 +2,8 @@ package parser
 
 import (
 	"go/ast"
+	"go/token"
+	"go/types"
 	"strings"
 
 	"github.com/promonkeyli/goas/pkg/model"
@@ -14,6 +16,10 @@ type Processor struct {
 	// Value: 包对象
 	PackagesMap map[string]*packages.Package
 
+	// 扫描的包 (Dirs 中的包) 及其所在的 module，用于区分项目代码与依赖
+	scannedPackages map[string]bool
+	scannedModules  map[string]bool
+
 	// 你的 OpenAPI 结果
 	OpenAPI *model.T
 
@@ -21,19 +27,87 @@ type Processor struct {
 	// Key: 全限定类型名 (e.g., "github.com/myproject/models.User")
 	GeneratedSchemas map[string]string
 
+	// 正在内联的命名结构体 (typeKey)，结构体引用自身时改为注册到 Components 并引用
+	inlining map[string]bool
+
+	// Components 中已占用的 Schema 名称
+	// Key: Schema 名称, Value: 占用该名称的类型 (typeKey)
+	componentOwners map[string]string
+
 	// 当前解析的请求/响应 MIME 类型
 	acceptTypes  []string
 	produceTypes []string
 
-	// 标记是否已解析全局注释
-	globalParsed bool
+	// 全局注释的来源 (package 注释、@GoasGlobal 标记的声明、main 函数)，扫描结束后统一选择并解析
+	globalSources []globalSource
+
+	// 解析过程中收集的诊断信息
+	Diagnostics Diagnostics
+
+	// 解析选项
+	opts Options
+
+	// 类型、字段、常量的文档注释
+	// Key: 声明名称的位置 (与 types.Object.Pos() 一致)
+	docs map[token.Pos]string
+
+	// 类型声明上的 @Schema 注解
+	// Key: 类型名称的位置 (与 types.TypeName.Pos() 一致)
+	schemaAnnotations map[token.Pos]schemaAnnotation
+	// 正在解析 @Schema 注解的类型，防止注解引用自身
+	resolvingAnnotations map[*types.TypeName]bool
+	// 已提示过的 json.Marshaler 类型，每个类型只提示一次
+	warnedMarshalers map[*types.TypeName]bool
+	// 已提示过的不合规 Schema 名称，每个名称只提示一次
+	warnedSchemaNames map[string]bool
+
+	// 源码位置信息，用于将 token.Pos 转换为 文件:行号
+	fset *token.FileSet
+	// 当前正在解析的注释行位置
+	pos token.Pos
+
+	// 从路由注册代码中发现的路由
+	// Key: 处理函数
+	routes map[*types.Func][]route
+
+	// 已定义的路由，Key 为 routeKey，Value 为定义位置
+	routeOwners map[string]token.Pos
+	// 已分配的 OperationID
+	operationIDs map[string]*operationOwner
+
+	// 接口中引用的安全方案与标签，扫描结束后统一校验是否已声明
+	securityRefs []annotationRef
+	tagRefs      []annotationRef
+	// 输出到 additionalOperations 的方法，扫描结束后校验文档版本是否支持
+	additionalOps []annotationRef
 }
 
-func newProcessor() *Processor {
+// annotationRef 注解中对某个名称的引用及其位置
+type annotationRef struct {
+	name string
+	pos  token.Pos
+}
+
+func newProcessor(fset *token.FileSet, opts Options) *Processor {
 	return &Processor{
 		PackagesMap:      make(map[string]*packages.Package),
+		scannedPackages:  make(map[string]bool),
+		scannedModules:   make(map[string]bool),
 		GeneratedSchemas: make(map[string]string),
+		inlining:         make(map[string]bool),
+		componentOwners:  make(map[string]string),
 		OpenAPI:          &model.T{},
+		opts:             opts,
+		docs:             make(map[token.Pos]string),
+		fset:             fset,
+
+		schemaAnnotations:    make(map[token.Pos]schemaAnnotation),
+		resolvingAnnotations: make(map[*types.TypeName]bool),
+		warnedMarshalers:     make(map[*types.TypeName]bool),
+		warnedSchemaNames:    make(map[string]bool),
+		routes:               make(map[*types.Func][]route),
+		routeOwners:          make(map[string]token.Pos),
+		operationIDs:         make(map[string]*operationOwner),
 	}
 }
 
@@ -45,6 +119,7 @@ func (p *Processor) addToIndex(pkg *packages.Package) {
 	}
 
 	p.PackagesMap[pkg.PkgPath] = pkg
+	p.indexDocs(pkg)
 
 	// 也可以选择递归把 imports 里的包也加进来，
 	// 这样即使 dirs 没写 model 包，只要 controller 引用了，我们也能查到。
@@ -53,6 +128,24 @@ func (p *Processor) addToIndex(pkg *packages.Package) {
 	}
 }
 
+// addScanned 记录扫描的包及其所在的 module
+func (p *Processor) addScanned(pkg *packages.Package) {
+	p.scannedPackages[pkg.PkgPath] = true
+	if pkg.Module != nil {
+		p.scannedModules[pkg.Module.Path] = true
+	}
+}
+
+// isScannedPackage 检查包是否属于项目代码: 扫描的包本身，或与扫描的包位于同一 module 的包 (如只被引用的 model 包)
+// 标准库与第三方依赖不属于项目代码
+func (p *Processor) isScannedPackage(pkgPath string) bool {
+	if p.scannedPackages[pkgPath] {
+		return true
+	}
+	pkg := p.PackagesMap[pkgPath]
+	return pkg != nil && pkg.Module != nil && p.scannedModules[pkg.Module.Path]
+}
+
 // scanPackage 扫描单个包里的 AST
 func (p *Processor) scanPackage(pkg *packages.Package) {
 	// 跳过标准库 (可选优化，避免扫描 fmt, net/http 等)
@@ -61,21 +154,41 @@ func (p *Processor) scanPackage(pkg *packages.Package) {
 	}
 
 	for _, file := range pkg.Syntax {
+		// @GoasGlobal 标记或带有全局注解的 package 注释 -> 全局注释
+		// 只有接口注解 (如 @Deprecated) 的 package 注释不是全局注释的来源
+		if hasAnnotation(file.Doc, TagGoasGlobal) {
+			p.addGlobalSource(pkg, file.Doc, globalFromMarker)
+		} else if hasGlobalAnnotation(file.Doc) {
+			p.addGlobalSource(pkg, file.Doc, globalFromPackageDoc)
+		}
+
 		ast.Inspect(file, func(n ast.Node) bool {
+			// @GoasGlobal 标记的变量、常量、类型声明 -> 全局注释
+			if decl, ok := n.(*ast.GenDecl); ok {
+				for _, doc := range genDeclDocs(decl) {
+					if hasAnnotation(doc, TagGoasGlobal) {
+						p.addGlobalSource(pkg, doc, globalFromMarker)
+					}
+				}
+				return true
+			}
+
 			fn, ok := n.(*ast.FuncDecl)
 			if !ok || fn.Doc == nil {
 				return true
 			}
 
 			// 检测函数类型并调用对应的解析逻辑
-			if p.isMainFunc(pkg, fn) {
-				// main 包的 main 函数 -> 解析全局注释
-				if !p.globalParsed {
-					p.parseGlobalAnnotations(pkg, file, fn)
-					p.globalParsed = true
+			if hasAnnotation(fn.Doc, TagGoasGlobal) {
+				// @GoasGlobal 标记的函数 -> 全局注释
+				p.addGlobalSource(pkg, fn.Doc, globalFromMarker)
+			} else if p.isMainFunc(pkg, fn) {
+				// main 包的 main 函数 -> 全局注释
+				if hasAnnotations(fn.Doc) {
+					p.addGlobalSource(pkg, fn.Doc, globalFromMain)
 				}
-			} else if p.hasRouterAnnotation(fn) {
-				// 有 @Router 注解的函数 -> 解析接口注释
+			} else if p.includesPackage(pkg.PkgPath) && (p.hasRouterAnnotation(fn) || len(p.handlerRoutes(pkg, fn)) > 0) {
+				// 有 @Router 注解或在路由注册中被引用的函数 -> 解析接口注释 (不在 Options.Packages 中的包只提供全局注释)
 				p.resetMimeTypes()
 				p.parseOperation(pkg, file, fn)
 			}
@@ -105,6 +218,15 @@ func (p *Processor) hasRouterAnnotation(fn *ast.FuncDecl) bool {
 	return false
 }
 
+// handlerRoutes 获取路由发现阶段绑定到该函数的路由
+func (p *Processor) handlerRoutes(pkg *packages.Package, fn *ast.FuncDecl) []route {
+	obj, ok := pkg.TypesInfo.Defs[fn.Name].(*types.Func)
+	if !ok {
+		return nil
+	}
+	return p.routes[obj]
+}
+
 // resetMimeTypes 重置 MIME 类型 (每个接口单独处理)
 func (p *Processor) resetMimeTypes() {
 	p.acceptTypes = nil
@@ -0,0 +1,530 @@
+package parser
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"path"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// RouteKind 路由注册调用的类型
+type RouteKind int
+
+const (
+	// RouteHandle 注册处理函数: r.GET("/users/:id", h.Get)
+	RouteHandle RouteKind = iota
+	// RouteGroup 创建带前缀的子路由，调用的返回值是子路由: r.Group("/api")
+	// 设置 Scope 时，子路由同时作为 Scope 函数的第一个参数: r.Route("/users", func(r chi.Router) {...})
+	RouteGroup
+	// RouteMount 将另一个路由对象 (Handler) 挂载到路径下: r.Mount("/admin", adminRouter())
+	RouteMount
+)
+
+// RouteCall 识别出的一次路由注册调用
+type RouteCall struct {
+	Kind RouteKind
+	// Router 接收注册的路由对象表达式 (如 r.GET 中的 r)，nil 表示根路由
+	Router ast.Expr
+	// Methods HTTP 方法 (小写)，仅 RouteHandle
+	Methods []string
+	// AnyMethod 注册的路由匹配所有 HTTP 方法 (如没有方法的 ServeMux 模式 "/static/")，
+	// Methods 为文档中使用的方法，没有 @Router 注解时给出提示
+	AnyMethod bool
+	// Path 框架语法的路径 (如 /users/:id)
+	Path string
+	// Handler 处理函数表达式 (RouteHandle) 或被挂载的路由对象表达式 (RouteMount)
+	Handler ast.Expr
+	// Scope 接收子路由的函数 (函数字面量或函数名)，仅 RouteGroup
+	Scope ast.Expr
+}
+
+// RouteExtractor 识别某个 Web 框架的路由注册调用
+// 内置 gin、chi、echo 与 net/http ServeMux 的实现，可以通过 Options.RouteExtractors 添加其他框架
+type RouteExtractor interface {
+	// Extract 识别路由注册调用，不是该框架的调用时返回 nil
+	Extract(call *ast.CallExpr, info *types.Info) *RouteCall
+	// ConvertPath 将框架语法的路径转换为 OpenAPI 路径模板: /users/:id -> /users/{id}
+	ConvertPath(path string) string
+}
+
+// route 从路由注册代码中发现的一个接口
+type route struct {
+	method string
+	// OpenAPI 路径模板
+	path string
+	// 注册位置
+	pos token.Pos
+	// 注册的路由匹配所有 HTTP 方法，method 只是文档中使用的方法
+	anyMethod bool
+}
+
+// typedExpr 表达式及其所在包的类型信息
+type typedExpr struct {
+	expr ast.Expr
+	info *types.Info
+}
+
+// routeIndex 路由发现过程中建立的索引
+// 路由对象经常在变量、函数参数之间传递 (如 v1 := r.Group("/v1"); registerUser(v1))，
+// 通过记录赋值和调用关系，可以从注册调用的接收者回溯出完整的路径前缀
+type routeIndex struct {
+	// 变量 (包括结构体字段) 被赋予的值
+	assigns map[*types.Var][]typedExpr
+	// 函数的调用位置
+	calls map[*types.Func][]typedExpr
+	// 函数参数所属的函数及其位置
+	params map[*types.Var]paramRef
+	// 函数声明，用于查找挂载的子路由 (函数返回值)
+	funcs map[*types.Func]typedFunc
+	// 识别出的路由注册调用
+	registrations []registration
+	// 正在计算前缀的变量，防止循环赋值导致死循环
+	visiting map[*types.Var]bool
+}
+
+// paramRef 函数参数在函数签名中的位置
+type paramRef struct {
+	fn    *types.Func
+	index int
+}
+
+// typedFunc 函数声明及其所在包的类型信息
+type typedFunc struct {
+	decl *ast.FuncDecl
+	info *types.Info
+}
+
+// registration 路由注册调用及其所属框架
+type registration struct {
+	call      *RouteCall
+	expr      *ast.CallExpr
+	info      *types.Info
+	extractor RouteExtractor
+}
+
+// discoverRoutes 扫描包中的路由注册代码，将发现的路由按处理函数记录到 p.routes
+func (p *Processor) discoverRoutes(pkgs []*packages.Package, extractors []RouteExtractor) {
+	idx := &routeIndex{
+		assigns:  make(map[*types.Var][]typedExpr),
+		calls:    make(map[*types.Func][]typedExpr),
+		params:   make(map[*types.Var]paramRef),
+		funcs:    make(map[*types.Func]typedFunc),
+		visiting: make(map[*types.Var]bool),
+	}
+
+	for _, pkg := range pkgs {
+		if isStandardLibrary(pkg.PkgPath) || pkg.TypesInfo == nil {
+			continue
+		}
+		for _, file := range pkg.Syntax {
+			idx.collect(file, pkg.TypesInfo, extractors)
+		}
+	}
+	idx.linkSubRouters()
+
+	for _, reg := range idx.registrations {
+		if reg.call.Kind != RouteHandle {
+			continue
+		}
+		handler := handlerFunc(reg.call.Handler, reg.info)
+		if handler == nil {
+			continue
+		}
+		for _, prefix := range idx.prefixes(reg.call.Router, reg.info, extractors) {
+			fullPath := reg.extractor.ConvertPath(joinRoutePath(prefix, reg.call.Path))
+			for _, method := range reg.call.Methods {
+				p.routes[handler] = append(p.routes[handler], route{
+					method:    method,
+					path:      fullPath,
+					pos:       reg.expr.Pos(),
+					anyMethod: reg.call.AnyMethod,
+				})
+			}
+		}
+	}
+}
+
+// collect 记录文件中的赋值、函数调用、函数参数以及路由注册调用
+func (idx *routeIndex) collect(file *ast.File, info *types.Info, extractors []RouteExtractor) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncDecl:
+			if fn, ok := info.Defs[node.Name].(*types.Func); ok {
+				idx.collectParams(fn)
+				idx.funcs[fn] = typedFunc{node, info}
+			}
+
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				return true
+			}
+			for i, lhs := range node.Lhs {
+				if v := exprVar(lhs, info); v != nil {
+					idx.assigns[v] = append(idx.assigns[v], typedExpr{node.Rhs[i], info})
+				}
+			}
+
+		case *ast.ValueSpec:
+			if len(node.Names) != len(node.Values) {
+				return true
+			}
+			for i, name := range node.Names {
+				if v, ok := info.Defs[name].(*types.Var); ok {
+					idx.assigns[v] = append(idx.assigns[v], typedExpr{node.Values[i], info})
+				}
+			}
+
+		case *ast.KeyValueExpr:
+			// 结构体字面量中的字段赋值: &Server{router: r}
+			if key, ok := node.Key.(*ast.Ident); ok {
+				if v, ok := info.Uses[key].(*types.Var); ok && v.IsField() {
+					idx.assigns[v] = append(idx.assigns[v], typedExpr{node.Value, info})
+				}
+			}
+
+		case *ast.CallExpr:
+			if fn := calledFunc(node, info); fn != nil {
+				idx.calls[fn] = append(idx.calls[fn], typedExpr{node, info})
+			}
+			for _, ex := range extractors {
+				if call := ex.Extract(node, info); call != nil {
+					idx.registrations = append(idx.registrations, registration{
+						call:      call,
+						expr:      node,
+						info:      info,
+						extractor: ex,
+					})
+					break
+				}
+			}
+		}
+		return true
+	})
+}
+
+// collectParams 记录函数参数 (包括方法) 的位置
+func (idx *routeIndex) collectParams(fn *types.Func) {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok {
+		return
+	}
+	for i := 0; i < sig.Params().Len(); i++ {
+		idx.params[sig.Params().At(i)] = paramRef{fn: fn, index: i}
+	}
+}
+
+// linkSubRouters 将子路由视为对变量的赋值，使其前缀可以像普通变量一样回溯
+//   - r.Route("/users", func(r chi.Router) {...}): 函数参数 r 被赋予 r.Route(...) 的返回值
+//   - r.Mount("/admin", adminRouter()): adminRouter 返回的路由变量被赋予挂载位置
+func (idx *routeIndex) linkSubRouters() {
+	for _, reg := range idx.registrations {
+		switch {
+		case reg.call.Kind == RouteGroup && reg.call.Scope != nil:
+			if v := scopeParam(reg.call.Scope, reg.info); v != nil {
+				idx.assigns[v] = append(idx.assigns[v], typedExpr{reg.expr, reg.info})
+			}
+
+		case reg.call.Kind == RouteMount:
+			for _, v := range idx.routerVars(reg.call.Handler, reg.info, make(map[*types.Func]bool)) {
+				idx.assigns[v] = append(idx.assigns[v], typedExpr{reg.expr, reg.info})
+			}
+		}
+	}
+}
+
+// scopeParam 获取接收子路由的函数的第一个参数
+func scopeParam(expr ast.Expr, info *types.Info) *types.Var {
+	var sig *types.Signature
+	if lit, ok := ast.Unparen(expr).(*ast.FuncLit); ok {
+		sig, _ = info.TypeOf(lit).(*types.Signature)
+	} else if fn := handlerFunc(expr, info); fn != nil {
+		sig = fn.Signature()
+	}
+	if sig == nil || sig.Params().Len() == 0 {
+		return nil
+	}
+	return sig.Params().At(0)
+}
+
+// routerVars 获取表达式引用的路由变量，函数调用取其返回的变量: adminRouter() -> adminRouter 中的 r
+func (idx *routeIndex) routerVars(expr ast.Expr, info *types.Info, visiting map[*types.Func]bool) []*types.Var {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+		if v := exprVar(e, info); v != nil {
+			return []*types.Var{v}
+		}
+
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			return idx.routerVars(e.X, info, visiting)
+		}
+
+	case *ast.CallExpr:
+		fn := calledFunc(e, info)
+		decl, ok := idx.funcs[fn]
+		if fn == nil || !ok || decl.decl.Body == nil || visiting[fn] {
+			return nil
+		}
+		visiting[fn] = true
+
+		var vars []*types.Var
+		ast.Inspect(decl.decl.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.FuncLit:
+				// 闭包中的 return 不是该函数的返回值
+				return false
+			case *ast.ReturnStmt:
+				if len(node.Results) == 1 {
+					vars = append(vars, idx.routerVars(node.Results[0], decl.info, visiting)...)
+				}
+			}
+			return true
+		})
+		return vars
+	}
+	return nil
+}
+
+// prefixes 计算路由对象表达式可能的路径前缀 (框架语法)
+// 同一个路由对象可能经由不同调用传入 (如同一个注册函数被多个分组调用)，因此返回多个前缀
+// 无法确定来源时视为根路由，返回 [""]
+func (idx *routeIndex) prefixes(expr ast.Expr, info *types.Info, extractors []RouteExtractor) []string {
+	if result := idx.resolvePrefixes(expr, info, extractors); len(result) > 0 {
+		return uniqueStrings(result)
+	}
+	return []string{""}
+}
+
+// resolvePrefixes 回溯路由对象表达式的来源，无法确定来源 (如 gin.New()) 时返回 nil
+func (idx *routeIndex) resolvePrefixes(expr ast.Expr, info *types.Info, extractors []RouteExtractor) []string {
+	if expr == nil {
+		return nil
+	}
+
+	var result []string
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CallExpr:
+		// r.Group("/api")、r.Route("/users", fn)、r.Mount("/admin", sub)
+		for _, ex := range extractors {
+			call := ex.Extract(e, info)
+			if call == nil || call.Kind == RouteHandle {
+				continue
+			}
+			for _, prefix := range idx.prefixes(call.Router, info, extractors) {
+				result = append(result, joinRoutePath(prefix, call.Path))
+			}
+			break
+		}
+
+	case *ast.UnaryExpr:
+		// &router
+		if e.Op == token.AND {
+			return idx.resolvePrefixes(e.X, info, extractors)
+		}
+
+	case *ast.StarExpr:
+		return idx.resolvePrefixes(e.X, info, extractors)
+
+	case *ast.Ident, *ast.SelectorExpr:
+		v := exprVar(e, info)
+		if v == nil || idx.visiting[v] {
+			break
+		}
+		idx.visiting[v] = true
+		defer delete(idx.visiting, v)
+
+		// 变量的赋值来源
+		for _, value := range idx.assigns[v] {
+			result = append(result, idx.resolvePrefixes(value.expr, value.info, extractors)...)
+		}
+
+		// 函数参数: 回溯到所有调用位置传入的实参
+		if ref, ok := idx.params[v]; ok {
+			for _, site := range idx.calls[ref.fn] {
+				call := site.expr.(*ast.CallExpr)
+				if ref.index < len(call.Args) {
+					result = append(result, idx.resolvePrefixes(call.Args[ref.index], site.info, extractors)...)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// exprVar 获取标识符或选择器表达式 (x.field) 引用的变量
+func exprVar(expr ast.Expr, info *types.Info) *types.Var {
+	var ident *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return nil
+	}
+
+	if v, ok := info.Defs[ident].(*types.Var); ok {
+		return v
+	}
+	if v, ok := info.Uses[ident].(*types.Var); ok {
+		return v.Origin()
+	}
+	return nil
+}
+
+// calledFunc 获取调用表达式调用的具名函数或方法
+func calledFunc(call *ast.CallExpr, info *types.Info) *types.Func {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	case *ast.IndexExpr:
+		// 泛型函数显式实例化: F[T](...)
+		return calledFunc(&ast.CallExpr{Fun: fun.X}, info)
+	default:
+		return nil
+	}
+
+	if fn, ok := info.Uses[ident].(*types.Func); ok {
+		return fn.Origin()
+	}
+	return nil
+}
+
+// handlerFunc 获取注册的处理函数
+// 支持函数名、方法值 (h.GetUser)、类型转换 (http.HandlerFunc(h.GetUser)) 以及返回处理函数的工厂函数调用 (h.GetUser())，
+// 工厂函数上的注释被视为接口注释；函数字面量无法关联注释，返回 nil
+func handlerFunc(expr ast.Expr, info *types.Info) *types.Func {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		fn, _ := info.Uses[e].(*types.Func)
+		if fn != nil {
+			return fn.Origin()
+		}
+	case *ast.SelectorExpr:
+		fn, _ := info.Uses[e.Sel].(*types.Func)
+		if fn != nil {
+			return fn.Origin()
+		}
+	case *ast.CallExpr:
+		// 类型转换: http.HandlerFunc(h.Get)
+		if tv, ok := info.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			return handlerFunc(e.Args[0], info)
+		}
+		return calledFunc(e, info)
+	}
+	return nil
+}
+
+// constString 获取常量字符串表达式的值 (字面量、常量及其拼接)
+func constString(expr ast.Expr, info *types.Info) (string, bool) {
+	tv, ok := info.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(tv.Value), true
+}
+
+// constStrings 获取常量字符串切片字面量的值: []string{"GET", http.MethodPost}
+func constStrings(expr ast.Expr, info *types.Info) []string {
+	lit, ok := ast.Unparen(expr).(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+	var values []string
+	for _, elt := range lit.Elts {
+		value, ok := constString(elt, info)
+		if !ok {
+			return nil
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// wildcardParam chi、echo 中匿名通配符 * 对应的路径参数名
+const wildcardParam = "path"
+
+// joinRoutePath 拼接路由前缀与路径，保留路径末尾的 / (与 gin 的 joinPaths 一致)
+// 路径为空时即前缀本身，根路由上为 /
+func joinRoutePath(prefix, relative string) string {
+	if relative == "" {
+		if prefix == "" {
+			return "/"
+		}
+		return prefix
+	}
+	joined := path.Join("/", prefix, relative)
+	if strings.HasSuffix(relative, "/") && !strings.HasSuffix(joined, "/") {
+		joined += "/"
+	}
+	return joined
+}
+
+// isNamedType 检查类型 (或其指针) 是否是指定包中的指定类型之一
+func isNamedType(t types.Type, pkgPath string, names ...string) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != pkgPath {
+		return false
+	}
+	for _, name := range names {
+		if named.Obj().Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueStrings 去重并保持原有顺序
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := values[:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// routeOperationID 为注册到多个路由的处理函数生成 OperationID
+// 输入: ("GetUser", "get", "/users/{id}") -> "GetUser_get_users_id"
+func routeOperationID(base, method, routePath string) string {
+	parts := []string{base, method}
+	for _, seg := range strings.Split(routePath, "/") {
+		seg = strings.Trim(seg, "{}")
+		if seg != "" {
+			parts = append(parts, sanitizeSchemaName(seg))
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+// checkRouterAnnotation 检查 @Router 是否与路由注册代码一致
+// 处理函数被注册到路由，但 @Router 的方法或路径与所有注册都不匹配时给出警告
+func (p *Processor) checkRouterAnnotation(annotated route, registered []route) {
+	if len(registered) == 0 {
+		return
+	}
+
+	var found []string
+	for _, r := range registered {
+		if (r.method == annotated.method || r.anyMethod) && r.path == annotated.path {
+			return
+		}
+		found = append(found, fmt.Sprintf("%s [%s] (%s)", r.path, r.method, p.fset.Position(r.pos)))
+	}
+	p.report(annotated.pos, SeverityWarning, fmt.Sprintf("@Router %s [%s] 与注册的路由不一致: %s",
+		annotated.path, annotated.method, strings.Join(found, ", ")))
+}
@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// chi 的包路径 (v5 与 v1.5 之前的版本)
+var chiPkgPaths = []string{"github.com/go-chi/chi/v5", "github.com/go-chi/chi"}
+
+// chi 路由注册方法对应的 HTTP 方法
+var chiMethods = map[string]string{
+	"Get":     "get",
+	"Post":    "post",
+	"Put":     "put",
+	"Patch":   "patch",
+	"Delete":  "delete",
+	"Head":    "head",
+	"Options": "options",
+	"Trace":   "trace",
+}
+
+// chiExtractor 识别 chi 的路由注册
+//
+//	r := chi.NewRouter()
+//	r.Route("/users", func(r chi.Router) {
+//		r.Get("/{id}", h.GetUser)
+//	})
+//	r.Mount("/admin", adminRouter())
+//	r.With(auth).Post("/login", h.Login)
+type chiExtractor struct{}
+
+func (chiExtractor) Extract(call *ast.CallExpr, info *types.Info) *RouteCall {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	recv := info.TypeOf(sel.X)
+	if recv == nil || !isChiRouter(recv) {
+		return nil
+	}
+
+	args := call.Args
+	switch name := sel.Sel.Name; {
+	case name == "Route" && len(args) == 2:
+		routePath, ok := constString(args[0], info)
+		if !ok {
+			return nil
+		}
+		return &RouteCall{Kind: RouteGroup, Router: sel.X, Path: routePath, Scope: args[1]}
+
+	case name == "Group" && len(args) == 1:
+		return &RouteCall{Kind: RouteGroup, Router: sel.X, Scope: args[0]}
+
+	case name == "With":
+		// r.With(middleware).Get(...): 不改变路径的子路由
+		return &RouteCall{Kind: RouteGroup, Router: sel.X}
+
+	case name == "Mount" && len(args) == 2:
+		routePath, ok := constString(args[0], info)
+		if !ok {
+			return nil
+		}
+		return &RouteCall{Kind: RouteMount, Router: sel.X, Path: routePath, Handler: args[1]}
+
+	case (name == "Handle" || name == "HandleFunc") && len(args) == 2:
+		// chi v5 同样支持 "GET /users" 形式的模式
+		pattern, ok := constString(args[0], info)
+		if !ok {
+			return nil
+		}
+		call := serveMuxRoute(sel.X, pattern, args[1])
+		call.Path = chiRoutePath(call.Path)
+		return call
+
+	case (name == "Method" || name == "MethodFunc") && len(args) == 3:
+		method, ok1 := constString(args[0], info)
+		routePath, ok2 := constString(args[1], info)
+		if !ok1 || !ok2 {
+			return nil
+		}
+		return &RouteCall{
+			Kind:    RouteHandle,
+			Router:  sel.X,
+			Methods: []string{strings.ToLower(method)},
+			Path:    chiRoutePath(routePath),
+			Handler: args[2],
+		}
+
+	case chiMethods[name] != "" && len(args) == 2:
+		routePath, ok := constString(args[0], info)
+		if !ok {
+			return nil
+		}
+		return &RouteCall{
+			Kind:    RouteHandle,
+			Router:  sel.X,
+			Methods: []string{chiMethods[name]},
+			Path:    chiRoutePath(routePath),
+			Handler: args[1],
+		}
+	}
+	return nil
+}
+
+// ConvertPath 转换 chi 路径参数: /users/{id:[0-9]+}/* -> /users/{id}/{path}
+// 末尾的通配符 * 没有名称，使用 {path} 作为参数名 (处理函数中通过 chi.URLParam(r, "*") 获取)
+func (chiExtractor) ConvertPath(routePath string) string {
+	var b strings.Builder
+	depth := 0
+	inPattern := false
+	for i := 0; i < len(routePath); i++ {
+		c := routePath[i]
+		switch {
+		case c == '{':
+			depth++
+			if depth > 1 {
+				continue
+			}
+		case c == '}':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			inPattern = false
+		case depth == 1 && c == ':':
+			inPattern = true
+			continue
+		case depth > 1 || inPattern:
+			continue
+		case c == '*' && depth == 0:
+			b.WriteString("{" + wildcardParam + "}")
+			continue
+		}
+		b.WriteByte(c)
+	}
+
+	return b.String()
+}
+
+// chiRoutePath 注册路径为 / 时匹配路由对象自身的路径:
+// r.Route("/users", ...) 中的 r.Get("/", ...) 对应 /users 而不是 /users/，与 chi 的匹配行为一致
+// 返回空路径，拼接前缀时得到前缀本身
+func chiRoutePath(routePath string) string {
+	if routePath == "/" {
+		return ""
+	}
+	return routePath
+}
+
+// isChiRouter 检查类型是否是 chi 的路由对象 (chi.Router 或 *chi.Mux)
+func isChiRouter(t types.Type) bool {
+	for _, pkgPath := range chiPkgPaths {
+		if isNamedType(t, pkgPath, "Router", "Mux") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package parser
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// echo 的包路径
+var echoPkgPaths = []string{"github.com/labstack/echo/v4", "github.com/labstack/echo"}
+
+// echo 路由注册方法对应的 HTTP 方法
+var echoMethods = map[string][]string{
+	"GET":     {"get"},
+	"POST":    {"post"},
+	"PUT":     {"put"},
+	"PATCH":   {"patch"},
+	"DELETE":  {"delete"},
+	"HEAD":    {"head"},
+	"OPTIONS": {"options"},
+	"TRACE":   {"trace"},
+	"Any":     anyMethods,
+}
+
+// echoExtractor 识别 echo 的路由注册
+//
+//	e := echo.New()
+//	g := e.Group("/api", middleware.Logger())
+//	g.GET("/users/:id", h.GetUser)
+//	g.Match([]string{"PUT", "PATCH"}, "/users/:id", h.UpdateUser)
+type echoExtractor struct{}
+
+func (echoExtractor) Extract(call *ast.CallExpr, info *types.Info) *RouteCall {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	recv := info.TypeOf(sel.X)
+	if recv == nil || !isEchoRouter(recv) {
+		return nil
+	}
+
+	// echo 的处理函数紧跟在路径之后，其后是中间件
+	args := call.Args
+	switch name := sel.Sel.Name; {
+	case name == "Group" && len(args) >= 1:
+		prefix, ok := constString(args[0], info)
+		if !ok {
+			return nil
+		}
+		return &RouteCall{Kind: RouteGroup, Router: sel.X, Path: prefix}
+
+	case name == "Add" && len(args) >= 3:
+		method, ok1 := constString(args[0], info)
+		routePath, ok2 := constString(args[1], info)
+		if !ok1 || !ok2 {
+			return nil
+		}
+		return &RouteCall{
+			Kind:    RouteHandle,
+			Router:  sel.X,
+			Methods: []string{strings.ToLower(method)},
+			Path:    routePath,
+			Handler: args[2],
+		}
+
+	case name == "Match" && len(args) >= 3:
+		methods := constStrings(args[0], info)
+		routePath, ok := constString(args[1], info)
+		if len(methods) == 0 || !ok {
+			return nil
+		}
+		for i, method := range methods {
+			methods[i] = strings.ToLower(method)
+		}
+		return &RouteCall{
+			Kind:    RouteHandle,
+			Router:  sel.X,
+			Methods: methods,
+			Path:    routePath,
+			Handler: args[2],
+		}
+
+	case echoMethods[name] != nil && len(args) >= 2:
+		routePath, ok := constString(args[0], info)
+		if !ok {
+			return nil
+		}
+		return &RouteCall{
+			Kind:    RouteHandle,
+			Router:  sel.X,
+			Methods: echoMethods[name],
+			Path:    routePath,
+			Handler: args[1],
+		}
+	}
+	return nil
+}
+
+// ConvertPath 转换 echo 路径参数: /users/:id/* -> /users/{id}/{path}
+// 通配符 * 没有名称，使用 {path} 作为参数名 (处理函数中通过 c.Param("*") 获取)
+func (echoExtractor) ConvertPath(routePath string) string {
+	segments := strings.Split(routePath, "/")
+	for i, seg := range segments {
+		switch {
+		case seg == "*":
+			segments[i] = "{" + wildcardParam + "}"
+		case len(seg) > 1 && seg[0] == ':':
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// isEchoRouter 检查类型是否是 echo 的路由对象 (*echo.Echo 或 *echo.Group)
+func isEchoRouter(t types.Type) bool {
+	for _, pkgPath := range echoPkgPaths {
+		if isNamedType(t, pkgPath, "Echo", "Group") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+const ginPkgPath = "github.com/gin-gonic/gin"
+
+// gin 路由注册方法对应的 HTTP 方法
+var ginMethods = map[string][]string{
+	"GET":     {"get"},
+	"POST":    {"post"},
+	"PUT":     {"put"},
+	"PATCH":   {"patch"},
+	"DELETE":  {"delete"},
+	"HEAD":    {"head"},
+	"OPTIONS": {"options"},
+	"Any":     anyMethods,
+}
+
+// anyMethods 注册到所有方法的路由 (如 gin 与 echo 的 Any) 对应的 HTTP 方法
+var anyMethods = []string{"get", "post", "put", "patch", "delete", "head", "options", "trace"}
+
+// ginExtractor 识别 gin 的路由注册
+//
+//	r := gin.Default()
+//	v1 := r.Group("/api/v1")
+//	v1.GET("/users/:id", h.GetUser)
+//	v1.Handle("POST", "/users", h.CreateUser)
+type ginExtractor struct{}
+
+func (ginExtractor) Extract(call *ast.CallExpr, info *types.Info) *RouteCall {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	recv := info.TypeOf(sel.X)
+	if recv == nil || !isNamedType(recv, ginPkgPath, "Engine", "RouterGroup", "IRouter", "IRoutes") {
+		return nil
+	}
+
+	name := sel.Sel.Name
+	switch {
+	case name == "Group" && len(call.Args) >= 1:
+		prefix, ok := constString(call.Args[0], info)
+		if !ok {
+			return nil
+		}
+		return &RouteCall{Kind: RouteGroup, Router: sel.X, Path: prefix}
+
+	case name == "Handle" && len(call.Args) >= 3:
+		method, ok1 := constString(call.Args[0], info)
+		routePath, ok2 := constString(call.Args[1], info)
+		if !ok1 || !ok2 {
+			return nil
+		}
+		return &RouteCall{
+			Kind:    RouteHandle,
+			Router:  sel.X,
+			Methods: []string{strings.ToLower(method)},
+			Path:    routePath,
+			Handler: call.Args[len(call.Args)-1],
+		}
+
+	case ginMethods[name] != nil && len(call.Args) >= 2:
+		routePath, ok := constString(call.Args[0], info)
+		if !ok {
+			return nil
+		}
+		return &RouteCall{
+			Kind:    RouteHandle,
+			Router:  sel.X,
+			Methods: ginMethods[name],
+			Path:    routePath,
+			// 最后一个参数是处理函数，前面的是中间件
+			Handler: call.Args[len(call.Args)-1],
+		}
+	}
+	return nil
+}
+
+// ConvertPath 转换 gin 路径参数: /users/:id/*path -> /users/{id}/{path}
+func (ginExtractor) ConvertPath(routePath string) string {
+	segments := strings.Split(routePath, "/")
+	for i, seg := range segments {
+		if len(seg) > 1 && (seg[0] == ':' || seg[0] == '*') {
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+const httpPkgPath = "net/http"
+
+// serveMuxExtractor 识别标准库 http.ServeMux 的路由注册 (Go 1.22+ 模式语法)
+//
+//	mux := http.NewServeMux()
+//	mux.HandleFunc("GET /users/{id}", h.GetUser)
+//	mux.Handle("/static/{path...}", http.HandlerFunc(h.Static))
+//	http.HandleFunc("POST /login", Login)
+type serveMuxExtractor struct{}
+
+func (serveMuxExtractor) Extract(call *ast.CallExpr, info *types.Info) *RouteCall {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || (sel.Sel.Name != "Handle" && sel.Sel.Name != "HandleFunc") || len(call.Args) != 2 {
+		return nil
+	}
+
+	var router ast.Expr
+	if recv := info.TypeOf(sel.X); recv != nil && isNamedType(recv, httpPkgPath, "ServeMux") {
+		// mux.HandleFunc(...)
+		router = sel.X
+	} else if fn := calledFunc(call, info); fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != httpPkgPath ||
+		fn.Signature().Recv() != nil {
+		// 不是 http.HandleFunc(...) (注册到 DefaultServeMux)
+		return nil
+	}
+
+	pattern, ok := constString(call.Args[0], info)
+	if !ok {
+		return nil
+	}
+	return serveMuxRoute(router, pattern, call.Args[1])
+}
+
+// serveMuxRoute 根据 ServeMux 模式创建路由注册
+// 没有指定方法的模式匹配所有方法，文档中按 GET 处理并标记为 AnyMethod
+func serveMuxRoute(router ast.Expr, pattern string, handler ast.Expr) *RouteCall {
+	method, routePath := parseServeMuxPattern(pattern)
+	call := &RouteCall{
+		Kind:    RouteHandle,
+		Router:  router,
+		Methods: []string{method},
+		Path:    routePath,
+		Handler: handler,
+	}
+	if method == "" {
+		call.Methods = []string{"get"}
+		call.AnyMethod = true
+	}
+	return call
+}
+
+// ConvertPath 转换 ServeMux 路径通配符: /files/{path...} -> /files/{path}, /{$} -> /
+func (serveMuxExtractor) ConvertPath(routePath string) string {
+	segments := strings.Split(routePath, "/")
+	for i, seg := range segments {
+		switch {
+		case seg == "{$}":
+			segments[i] = ""
+		case strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "...}"):
+			segments[i] = strings.TrimSuffix(seg, "...}") + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// parseServeMuxPattern 解析 ServeMux 模式: [METHOD ][HOST]/[PATH]
+// 输入: "GET example.com/users/{id}" -> ("get", "/users/{id}")
+// 没有指定方法时 method 为空
+func parseServeMuxPattern(pattern string) (method, routePath string) {
+	if m, rest, ok := strings.Cut(strings.TrimSpace(pattern), " "); ok {
+		method = strings.ToLower(m)
+		pattern = strings.TrimSpace(rest)
+	}
+
+	// 去掉主机名部分
+	if i := strings.Index(pattern, "/"); i > 0 {
+		pattern = pattern[i:]
+	}
+	return method, pattern
+}
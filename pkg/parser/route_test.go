@@ -0,0 +1,182 @@
+package parser
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestConvertPath(t *testing.T) {
+	tests := []struct {
+		name      string
+		extractor RouteExtractor
+		path      string
+		want      string
+	}{
+		{"gin 路径参数", ginExtractor{}, "/users/:id", "/users/{id}"},
+		{"gin 通配参数", ginExtractor{}, "/files/*path", "/files/{path}"},
+		{"gin 多个参数", ginExtractor{}, "/users/:uid/orders/:oid", "/users/{uid}/orders/{oid}"},
+		{"gin 普通路径", ginExtractor{}, "/users/", "/users/"},
+
+		{"chi 路径参数", chiExtractor{}, "/users/{id}", "/users/{id}"},
+		{"chi 正则参数", chiExtractor{}, "/users/{id:[0-9]+}", "/users/{id}"},
+		{"chi 正则中的花括号", chiExtractor{}, "/users/{id:[0-9]{1,8}}/orders", "/users/{id}/orders"},
+		{"chi 通配符", chiExtractor{}, "/files/*", "/files/{path}"},
+		{"chi 保留末尾的 /", chiExtractor{}, "/users/", "/users/"},
+		{"chi 根路径", chiExtractor{}, "/", "/"},
+
+		{"echo 路径参数", echoExtractor{}, "/users/:id", "/users/{id}"},
+		{"echo 通配符", echoExtractor{}, "/static/*", "/static/{path}"},
+
+		{"ServeMux 路径参数", serveMuxExtractor{}, "/users/{id}", "/users/{id}"},
+		{"ServeMux 剩余路径", serveMuxExtractor{}, "/files/{path...}", "/files/{path}"},
+		{"ServeMux 精确匹配", serveMuxExtractor{}, "/{$}", "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.extractor.ConvertPath(tt.path); got != tt.want {
+				t.Errorf("ConvertPath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinRoutePath(t *testing.T) {
+	tests := []struct {
+		prefix   string
+		relative string
+		want     string
+	}{
+		{"", "/users", "/users"},
+		{"/api", "/users", "/api/users"},
+		{"/api/", "users", "/api/users"},
+		{"/api", "", "/api"},
+		{"/api", "/", "/api/"},
+		{"/api/v1/", "/users/", "/api/v1/users/"},
+		{"/api", "/users/:id", "/api/users/:id"},
+		{"", "", "/"},
+	}
+
+	for _, tt := range tests {
+		if got := joinRoutePath(tt.prefix, tt.relative); got != tt.want {
+			t.Errorf("joinRoutePath(%q, %q) = %q, want %q", tt.prefix, tt.relative, got, tt.want)
+		}
+	}
+}
+
+func TestParseServeMuxPattern(t *testing.T) {
+	tests := []struct {
+		pattern    string
+		wantMethod string
+		wantPath   string
+	}{
+		{"GET /users/{id}", "get", "/users/{id}"},
+		{"/users", "", "/users"},
+		{"POST example.com/login", "post", "/login"},
+		{"example.com/", "", "/"},
+		{"  DELETE   /users/{id} ", "delete", "/users/{id}"},
+	}
+
+	for _, tt := range tests {
+		method, path := parseServeMuxPattern(tt.pattern)
+		if method != tt.wantMethod || path != tt.wantPath {
+			t.Errorf("parseServeMuxPattern(%q) = (%q, %q), want (%q, %q)", tt.pattern, method, path, tt.wantMethod, tt.wantPath)
+		}
+	}
+}
+
+func TestRouteOperationID(t *testing.T) {
+	tests := []struct {
+		base, method, path string
+		want               string
+	}{
+		{"GetUser", "get", "/users/{id}", "GetUser_get_users_id"},
+		{"Purge", "purge", "/cache/{key}", "Purge_purge_cache_key"},
+		{"Root", "get", "/", "Root_get"},
+	}
+
+	for _, tt := range tests {
+		if got := routeOperationID(tt.base, tt.method, tt.path); got != tt.want {
+			t.Errorf("routeOperationID(%q, %q, %q) = %q, want %q", tt.base, tt.method, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestDiscoverRoutes(t *testing.T) {
+	openapi, _ := parseApp(t, Options{DiscoverRoutes: true})
+
+	tests := []struct {
+		name   string
+		path   string
+		method string
+		want   string
+	}{
+		{"gin 分组前缀", "/api/v1/accounts/{id}", "get", "GetAccount"},
+		{"gin 通配参数", "/api/v1/files/{path}", "get", "Files"},
+		{"gin Handle", "/ping", "post", "Ping"},
+
+		{"chi Route 子路由根路径", "/users", "get", "ListUsers"},
+		{"chi 正则参数", "/users/{id}", "get", "GetUser"},
+		{"chi Mount", "/admin/stats", "get", "Stats"},
+		{"chi Group + Method", "/files/{path}", "delete", "DeleteFile"},
+		{"chi 根路由的根路径", "/", "put", "Health"},
+		{"chi 保留末尾的 /", "/reports/", "get", "Reports"},
+
+		{"echo 分组前缀", "/v2/items/{id}", "get", "GetItem"},
+		{"echo 嵌套分组 Add", "/v2/admin/items", "post", "CreateItem"},
+		{"echo Match PUT", "/items/{id}", "put", "UpdateItem_put_items_id"},
+		{"echo Match PATCH", "/items/{id}", "patch", "UpdateItem_patch_items_id"},
+
+		{"ServeMux 精确匹配", "/", "get", "Index"},
+		{"ServeMux 路径参数", "/members/{id}", "get", "GetMember"},
+		{"ServeMux 无方法的模式", "/downloads/{path}", "get", "Download"},
+		{"http.HandleFunc", "/login", "post", "Login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			op := findOperation(openapi, tt.path, tt.method)
+			if op == nil {
+				t.Fatalf("未发现接口 [%s] %s", tt.method, tt.path)
+			}
+			if op.OperationID != tt.want {
+				t.Errorf("[%s] %s OperationID = %q, want %q", tt.method, tt.path, op.OperationID, tt.want)
+			}
+		})
+	}
+
+	t.Run("没有方法的 ServeMux 模式", func(t *testing.T) {
+		openapi, diags := parseApp(t, Options{DiscoverRoutes: true})
+		if !hasDiagnostic(diags, "路由 /downloads/{path} 没有指定 HTTP 方法") {
+			t.Errorf("缺少没有方法的路由警告: %v", diags)
+		}
+		// @Router 声明了方法时不提示，任何方法都与注册的路由一致
+		if op := findOperation(openapi, "/uploads", "post"); op == nil {
+			t.Error("未找到接口 [post] /uploads")
+		}
+		if hasDiagnostic(diags, "路由 /uploads 没有指定 HTTP 方法") || hasDiagnostic(diags, "@Router /uploads [post] 与注册的路由不一致") {
+			t.Errorf("@Router 声明的方法不应给出警告: %v", diags)
+		}
+	})
+
+	t.Run("匿名通配符作为 path 参数", func(t *testing.T) {
+		openapi, diags := parseApp(t, Options{DiscoverRoutes: true, AutoPathParams: true})
+		op := findOperation(openapi, "/files/{path}", "delete")
+		if op == nil {
+			t.Fatal("未找到接口 [delete] /files/{path}")
+		}
+		if got := paramKeys(op); !slices.Equal(got, []string{"path:path"}) {
+			t.Errorf("参数 = %v, want [path:path]", got)
+		}
+		if hasDiagnostic(diags, "{*}") {
+			t.Errorf("不应出现参数 {*}: %v", diags)
+		}
+	})
+
+	t.Run("未开启 DiscoverRoutes", func(t *testing.T) {
+		openapi, _ := parseApp(t, Options{})
+		if op := findOperation(openapi, "/ping", "post"); op != nil {
+			t.Errorf("未开启 DiscoverRoutes 时不应发现路由 /ping")
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"go/types"
 	"strings"
+	"unicode"
 
 	"github.com/promonkeyli/goas/pkg/model"
 	"golang.org/x/tools/go/packages"
@@ -80,89 +81,66 @@ func parseGenericType(typeName string) (base string, typeArgs []string) {
 
 // resolveGenericSchema 解析泛型类型的 Schema
 func (p *Processor) resolveGenericSchema(pkg *packages.Package, baseType string, typeArgs []string) *model.Schema {
-	// 生成唯一的 Schema 名称 (如 ResponseOfUser)
-	schemaName := generateGenericSchemaName(baseType, typeArgs)
+	// 优先实例化为 go/types 类型，与源码中引用的同一泛型实例共用一个 Schema
+	if inst := p.instantiate(pkg, baseType, typeArgs); inst != nil {
+		return p.typeToSchema(inst)
+	}
+
+	// 类型实参无法解析为 Go 类型 (如 integer)，按注解文本替换类型参数
+	// 解析包路径和类型名
+	pkgPath, shortName := parseTypePath(pkg, baseType)
 
 	// 检查缓存
-	if ref, ok := p.GeneratedSchemas[schemaName]; ok {
+	key := pkgPath + "." + shortName + "[" + strings.Join(typeArgs, ",") + "]"
+	if ref, ok := p.GeneratedSchemas[key]; ok {
 		return &model.Schema{Ref: ref}
 	}
 
+	// 生成唯一的 Schema 名称 (如 ResponseOfUser)
+	schemaName := p.claimComponentName(key, p.generateGenericSchemaName(baseType, typeArgs))
+
 	// 预先添加到缓存，防止循环引用
 	refPath := "#/components/schemas/" + schemaName
-	p.GeneratedSchemas[schemaName] = refPath
-
-	// 解析包路径和类型名
-	pkgPath, shortName := parseTypePath(pkg, baseType)
+	p.GeneratedSchemas[key] = refPath
 
 	// 查找类型定义
 	targetPkg := p.findPackage(pkgPath)
 	if targetPkg == nil || targetPkg.Types == nil {
+		p.errorf("无法找到泛型类型 %s 所在的包 %q", baseType, pkgPath)
 		return &model.Schema{Ref: refPath}
 	}
 
 	// 查找类型对象
 	obj := targetPkg.Types.Scope().Lookup(shortName)
 	if obj == nil {
+		p.errorf("包 %s 中不存在类型 %s", targetPkg.PkgPath, shortName)
 		return &model.Schema{Ref: refPath}
 	}
 
 	// 获取底层结构体
 	namedType, ok := obj.Type().(*types.Named)
 	if !ok {
+		p.errorf("%s 不是命名类型，无法作为泛型实例化", baseType)
 		return &model.Schema{Ref: refPath}
 	}
 
 	underlying, ok := namedType.Underlying().(*types.Struct)
 	if !ok {
+		p.errorf("泛型类型 %s 的底层类型不是结构体，暂不支持", baseType)
 		return &model.Schema{Ref: refPath}
 	}
 
 	// 创建实例化的 Schema
-	schema := &model.Schema{
-		Type:       "object",
-		Title:      schemaName,
-		Properties: make(map[string]*model.Schema),
-	}
-
-	// 遍历结构体字段
-	for i := 0; i < underlying.NumFields(); i++ {
-		field := underlying.Field(i)
-		tag := underlying.Tag(i)
-
-		if !field.Exported() {
-			continue
-		}
-
-		jsonName, omitempty := parseJSONTag(tag)
-		if jsonName == "-" {
-			continue
-		}
-		if jsonName == "" {
-			jsonName = field.Name()
-		}
-
-		// 使用带类型参数替换的类型解析 (使用 targetPkg 确保类型在正确的包上下文中解析)
-		fieldSchema := p.typeToSchemaWithSubstitution(targetPkg, field.Type(), typeArgs)
-
-		// 添加描述
-		if desc := parseDescTag(tag); desc != "" {
-			fieldSchema.Description = desc
-		}
-
-		schema.Properties[jsonName] = fieldSchema
-
-		if !omitempty {
-			schema.Required = append(schema.Required, jsonName)
-		}
+	// 使用带类型参数替换的类型解析 (使用 targetPkg 确保类型在正确的包上下文中解析)
+	schema := p.buildStructSchema(underlying, schemaName, func(t types.Type) *model.Schema {
+		return p.typeToSchemaWithSubstitution(targetPkg, t, typeArgs)
+	})
+	if schema.Description == "" {
+		schema.Description = p.namedDoc(obj.Pos(), obj.Name())
 	}
 
 	// 添加到 Components
-	p.ensureComponents()
-	if p.OpenAPI.Components.Schemas == nil {
-		p.OpenAPI.Components.Schemas = make(map[string]*model.Schema)
-	}
-	p.OpenAPI.Components.Schemas[schemaName] = schema
+	p.addComponentSchema(schemaName, schema)
 
 	return &model.Schema{Ref: refPath}
 }
@@ -170,12 +148,12 @@ func (p *Processor) resolveGenericSchema(pkg *packages.Package, baseType string,
 // generateGenericSchemaName 生成泛型 Schema 名称
 // 输入: ("Response", ["User"]) -> "ResponseOfUser"
 // 输入: ("Page", ["model.Item"]) -> "PageOfItem"
-func generateGenericSchemaName(base string, typeArgs []string) string {
+func (p *Processor) generateGenericSchemaName(base string, typeArgs []string) string {
 	var parts []string
 	for _, arg := range typeArgs {
 		// 递归处理嵌套泛型
 		if nestedBase, nestedArgs := parseGenericType(arg); nestedBase != "" {
-			parts = append(parts, generateGenericSchemaName(nestedBase, nestedArgs))
+			parts = append(parts, p.generateGenericSchemaName(nestedBase, nestedArgs))
 		} else {
 			// 取类型的最后一部分 (model.User -> User)
 			if idx := strings.LastIndex(arg, "."); idx != -1 {
@@ -185,7 +163,10 @@ func generateGenericSchemaName(base string, typeArgs []string) string {
 			}
 		}
 	}
-	return base + "Of" + strings.Join(parts, "And")
+	if idx := strings.LastIndex(base, "."); idx != -1 {
+		base = base[idx+1:]
+	}
+	return p.genericSchemaName(base, parts)
 }
 
 // instantiateGenericSchema 实例化泛型 Schema
@@ -198,16 +179,17 @@ func (p *Processor) instantiateGenericSchema(pkg *packages.Package, base *model.
 	}
 
 	if base.Properties != nil {
-		schema.Properties = make(map[string]*model.Schema)
-		for name, prop := range base.Properties {
+		schema.Properties = model.NewOrderedMap[*model.Schema]()
+		for _, name := range base.Properties.Keys() {
+			prop, _ := base.Properties.Get(name)
 			// 检查是否是类型参数占位符
 			if prop.Ref != "" && isTypeParamPlaceholder(prop.Ref) {
 				// 用实际类型替换
 				if len(typeArgs) > 0 {
-					schema.Properties[name] = p.resolveTypeSchema(pkg, typeArgs[0])
+					schema.Properties.Set(name, p.resolveTypeSchema(pkg, typeArgs[0]))
 				}
 			} else {
-				schema.Properties[name] = prop
+				schema.Properties.Set(name, prop)
 			}
 		}
 	}
@@ -230,6 +212,9 @@ func (p *Processor) typeToSchemaWithSubstitution(pkg *packages.Package, t types.
 		return p.typeToSchemaWithSubstitution(pkg, typ.Elem(), typeArgs)
 
 	case *types.Slice:
+		if isByteSlice(typ) {
+			return byteSliceSchema()
+		}
 		return &model.Schema{
 			Type:  "array",
 			Items: p.typeToSchemaWithSubstitution(pkg, typ.Elem(), typeArgs),
@@ -246,31 +231,47 @@ func (p *Processor) typeToSchemaWithSubstitution(pkg *packages.Package, t types.
 	case *types.Map:
 		return &model.Schema{
 			Type:                 "object",
+			PropertyNames:        p.mapKeySchema(typ.Key()),
 			AdditionalProperties: p.typeToSchemaWithSubstitution(pkg, typ.Elem(), typeArgs),
 		}
 
 	case *types.Named:
-		// 递归解析命名类型
-		obj := typ.Obj()
-		pkgPath := ""
-		if obj.Pkg() != nil {
-			pkgPath = obj.Pkg().Path()
-		}
-		fullName := pkgPath + "." + obj.Name()
-
 		// 检查缓存
-		if ref, ok := p.GeneratedSchemas[fullName]; ok {
+		if ref, ok := p.GeneratedSchemas[typeKey(typ)]; ok {
 			return &model.Schema{Ref: ref}
 		}
 
+		// 类型自身声明的 JSON 表现 (类型覆盖、常见类型、@Schema、自定义序列化)
+		if schema := p.declaredTypeSchema(typ); schema != nil {
+			return schema
+		}
+
 		// 处理泛型类型
 		if typeParams := typ.TypeParams(); typeParams != nil && typeParams.Len() > 0 {
 			return p.handleGenericNamedType(typ)
 		}
 
+		// 处理枚举类型 (带有同类型常量的基本类型)
+		if schema := p.enumSchema(typ); schema != nil {
+			return schema
+		}
+
+		// 命名结构体内联其字段 (已注册到 Components 的结构体在上方直接引用)
+		if _, ok := typ.Underlying().(*types.Struct); ok {
+			return p.inlineNamedStruct(typ, func(t types.Type) *model.Schema {
+				return p.typeToSchemaWithSubstitution(pkg, t, typeArgs)
+			})
+		}
+
 		// 解析底层类型
 		return p.typeToSchemaWithSubstitution(pkg, typ.Underlying(), typeArgs)
 
+	case *types.Alias:
+		if schema := p.knownTypeSchema(typ); schema != nil {
+			return schema
+		}
+		return p.typeToSchemaWithSubstitution(pkg, types.Unalias(typ), typeArgs)
+
 	case *types.Struct:
 		return p.structToSchema(nil, typ, "")
 
@@ -298,6 +299,9 @@ func isTypeParamPlaceholder(ref string) bool {
 // resolveStructSchema 解析结构体类型的 Schema
 func (p *Processor) resolveStructSchema(pkg *packages.Package, typeName string) *model.Schema {
 	// 处理 [] 切片类型
+	if typeName == "[]byte" || typeName == "[]uint8" {
+		return byteSliceSchema()
+	}
 	if strings.HasPrefix(typeName, "[]") {
 		itemType := strings.TrimPrefix(typeName, "[]")
 		return &model.Schema{
@@ -317,12 +321,6 @@ func (p *Processor) resolveStructSchema(pkg *packages.Package, typeName string)
 	// 解析包路径和类型名
 	pkgPath, shortName := parseTypePath(pkg, typeName)
 
-	// 检查缓存
-	fullName := pkgPath + "." + shortName
-	if ref, ok := p.GeneratedSchemas[fullName]; ok {
-		return &model.Schema{Ref: ref}
-	}
-
 	// 查找类型定义
 	// 如果类型在当前包中，直接使用当前包，避免 findPackage 查找失败
 	var targetPkg *packages.Package
@@ -333,36 +331,91 @@ func (p *Processor) resolveStructSchema(pkg *packages.Package, typeName string)
 	}
 	if targetPkg == nil || targetPkg.Types == nil {
 		// 无法找到包，返回空 object
+		p.errorf("无法找到类型 %s 所在的包 %q (请确认该包已被扫描或被导入)", typeName, pkgPath)
 		return &model.Schema{Type: "object"}
 	}
 
 	// 查找类型对象
 	obj := targetPkg.Types.Scope().Lookup(shortName)
 	if obj == nil {
+		p.errorf("包 %s 中不存在类型 %s", targetPkg.PkgPath, shortName)
 		return &model.Schema{Type: "object"}
 	}
 
-	typeDef, ok := obj.Type().Underlying().(*types.Struct)
+	return p.namedTypeSchema(obj.Type())
+}
+
+// namedTypeSchema 解析注解或绑定调用直接引用的类型，命名结构体注册到 Components 并引用
+// 结构体字段中的命名结构体由 typeToSchema 内联
+func (p *Processor) namedTypeSchema(t types.Type) *model.Schema {
+	named, ok := t.(*types.Named)
+	if !ok || named.TypeArgs().Len() > 0 {
+		// 可能是类型别名、基本类型或泛型实例
+		return p.typeToSchema(t)
+	}
+
+	// 类型自身声明的 JSON 表现 (类型覆盖、常见类型、@Schema、自定义序列化)
+	if schema := p.declaredTypeSchema(named); schema != nil {
+		return schema
+	}
+
+	return p.namedStructRef(named)
+}
+
+// namedStructRef 将命名结构体注册到 Components 并返回 $ref
+// 非结构体的命名类型直接内联其 Schema
+func (p *Processor) namedStructRef(named *types.Named) *model.Schema {
+	obj := named.Obj()
+
+	// 检查缓存
+	key := typeKey(named)
+	if ref, ok := p.GeneratedSchemas[key]; ok {
+		return &model.Schema{Ref: ref}
+	}
+
+	typeDef, ok := named.Underlying().(*types.Struct)
 	if !ok {
 		// 可能是类型别名或基本类型
-		return p.typeToSchema(obj.Type())
+		return p.typeToSchema(named)
 	}
 
 	// 预先添加到缓存，防止循环引用
-	refPath := "#/components/schemas/" + shortName
-	p.GeneratedSchemas[fullName] = refPath
+	schemaName := p.componentName(named)
+	refPath := "#/components/schemas/" + schemaName
+	p.GeneratedSchemas[key] = refPath
 
 	// 解析结构体字段
-	schema := p.structToSchema(targetPkg, typeDef, shortName)
+	schema := p.structToSchema(nil, typeDef, schemaName)
+	if schema.Description == "" {
+		schema.Description = p.namedDoc(obj.Pos(), obj.Name())
+	}
 
 	// 添加到 Components
+	p.addComponentSchema(schemaName, schema)
+
+	return &model.Schema{Ref: refPath}
+}
+
+// inlineNamedStruct 内联命名结构体的字段
+// 结构体引用自身时 (如 type Node struct{ Children []Node }) 改为注册到 Components 并引用，避免无限递归
+func (p *Processor) inlineNamedStruct(named *types.Named, typeToSchema func(types.Type) *model.Schema) *model.Schema {
+	key := typeKey(named)
+	if p.inlining[key] {
+		return p.namedStructRef(named)
+	}
+	p.inlining[key] = true
+	defer delete(p.inlining, key)
+
+	return typeToSchema(named.Underlying())
+}
+
+// addComponentSchema 将 Schema 添加到 Components.Schemas
+func (p *Processor) addComponentSchema(name string, schema *model.Schema) {
 	p.ensureComponents()
 	if p.OpenAPI.Components.Schemas == nil {
 		p.OpenAPI.Components.Schemas = make(map[string]*model.Schema)
 	}
-	p.OpenAPI.Components.Schemas[shortName] = schema
-
-	return &model.Schema{Ref: refPath}
+	p.OpenAPI.Components.Schemas[name] = schema
 }
 
 // parseTypePath 解析类型路径
@@ -415,47 +468,7 @@ func (p *Processor) findPackage(pkgPath string) *packages.Package {
 
 // structToSchema 将 Go 结构体转换为 Schema
 func (p *Processor) structToSchema(pkg *packages.Package, st *types.Struct, name string) *model.Schema {
-	schema := &model.Schema{
-		Type:       "object",
-		Title:      name,
-		Properties: make(map[string]*model.Schema),
-	}
-
-	for i := 0; i < st.NumFields(); i++ {
-		field := st.Field(i)
-		tag := st.Tag(i)
-
-		// 跳过未导出字段
-		if !field.Exported() {
-			continue
-		}
-
-		// 解析 json tag
-		jsonName, omitempty := parseJSONTag(tag)
-		if jsonName == "-" {
-			continue
-		}
-		if jsonName == "" {
-			jsonName = field.Name()
-		}
-
-		// 解析字段类型
-		fieldSchema := p.typeToSchema(field.Type())
-
-		// 添加字段描述 (从 tag 或 注释)
-		if desc := parseDescTag(tag); desc != "" {
-			fieldSchema.Description = desc
-		}
-
-		schema.Properties[jsonName] = fieldSchema
-
-		// 处理必填字段
-		if !omitempty {
-			schema.Required = append(schema.Required, jsonName)
-		}
-	}
-
-	return schema
+	return p.buildStructSchema(st, name, p.typeToSchema)
 }
 
 // typeToSchema 将 Go 类型转换为 Schema
@@ -468,6 +481,10 @@ func (p *Processor) typeToSchema(t types.Type) *model.Schema {
 		return p.typeToSchema(typ.Elem())
 
 	case *types.Slice:
+		// []byte 被 encoding/json 编码为 base64 字符串
+		if isByteSlice(typ) {
+			return byteSliceSchema()
+		}
 		return &model.Schema{
 			Type:  "array",
 			Items: p.typeToSchema(typ.Elem()),
@@ -484,31 +501,46 @@ func (p *Processor) typeToSchema(t types.Type) *model.Schema {
 	case *types.Map:
 		return &model.Schema{
 			Type:                 "object",
+			PropertyNames:        p.mapKeySchema(typ.Key()),
 			AdditionalProperties: p.typeToSchema(typ.Elem()),
 		}
 
 	case *types.Named:
-		// 递归解析命名类型
-		obj := typ.Obj()
-		pkgPath := ""
-		if obj.Pkg() != nil {
-			pkgPath = obj.Pkg().Path()
-		}
-		fullName := pkgPath + "." + obj.Name()
-
 		// 检查缓存
-		if ref, ok := p.GeneratedSchemas[fullName]; ok {
+		if ref, ok := p.GeneratedSchemas[typeKey(typ)]; ok {
 			return &model.Schema{Ref: ref}
 		}
 
+		// 类型自身声明的 JSON 表现 (类型覆盖、常见类型、@Schema、自定义序列化)
+		if schema := p.declaredTypeSchema(typ); schema != nil {
+			return schema
+		}
+
 		// 处理泛型类型 (Go 1.18+)
 		if typeParams := typ.TypeParams(); typeParams != nil && typeParams.Len() > 0 {
 			return p.handleGenericNamedType(typ)
 		}
 
+		// 处理枚举类型 (带有同类型常量的基本类型)
+		if schema := p.enumSchema(typ); schema != nil {
+			return schema
+		}
+
+		// 命名结构体内联其字段 (已注册到 Components 的结构体在上方直接引用)
+		if _, ok := typ.Underlying().(*types.Struct); ok {
+			return p.inlineNamedStruct(typ, p.typeToSchema)
+		}
+
 		// 解析底层类型
 		return p.typeToSchema(typ.Underlying())
 
+	case *types.Alias:
+		// 类型别名 (如 json.RawMessage)，先按别名本身查找常见类型，再解析实际类型
+		if schema := p.knownTypeSchema(typ); schema != nil {
+			return schema
+		}
+		return p.typeToSchema(types.Unalias(typ))
+
 	case *types.Struct:
 		// 内联匿名结构体
 		return p.structToSchema(nil, typ, "")
@@ -536,29 +568,18 @@ func (p *Processor) handleGenericNamedType(typ *types.Named) *model.Schema {
 		return p.typeToSchema(typ.Underlying())
 	}
 
-	// 构建类型参数字符串
-	var args []string
-	for i := 0; i < typeArgs.Len(); i++ {
-		arg := typeArgs.At(i)
-		if named, ok := arg.(*types.Named); ok {
-			args = append(args, named.Obj().Name())
-		} else {
-			args = append(args, arg.String())
-		}
-	}
-
-	// 生成 Schema 名称
-	baseName := typ.Obj().Name()
-	schemaName := generateGenericSchemaName(baseName, args)
-
 	// 检查缓存
-	if ref, ok := p.GeneratedSchemas[schemaName]; ok {
+	key := typeKey(typ)
+	if ref, ok := p.GeneratedSchemas[key]; ok {
 		return &model.Schema{Ref: ref}
 	}
 
+	// 生成 Schema 名称 (如 ResponseOfUser)
+	schemaName := p.componentName(typ)
+
 	// 解析并实例化
 	refPath := "#/components/schemas/" + schemaName
-	p.GeneratedSchemas[schemaName] = refPath
+	p.GeneratedSchemas[key] = refPath
 
 	// 解析底层结构体
 	underlying, ok := typ.Underlying().(*types.Struct)
@@ -566,44 +587,23 @@ func (p *Processor) handleGenericNamedType(typ *types.Named) *model.Schema {
 		return &model.Schema{Ref: refPath}
 	}
 
-	// 创建实例化的 Schema
-	schema := &model.Schema{
-		Type:       "object",
-		Title:      schemaName,
-		Properties: make(map[string]*model.Schema),
-	}
-
-	for i := 0; i < underlying.NumFields(); i++ {
-		field := underlying.Field(i)
-		tag := underlying.Tag(i)
-
-		if !field.Exported() {
-			continue
-		}
-
-		jsonName, omitempty := parseJSONTag(tag)
-		if jsonName == "-" {
-			continue
-		}
-		if jsonName == "" {
-			jsonName = field.Name()
+	// 类型实参中的命名结构体与注解中引用的类型一样注册到 Components，字段中通过 $ref 引用
+	for i := 0; i < typeArgs.Len(); i++ {
+		if arg, ok := types.Unalias(typeArgs.At(i)).(*types.Named); ok {
+			if _, ok := arg.Underlying().(*types.Struct); ok {
+				p.namedTypeSchema(arg)
+			}
 		}
+	}
 
-		// 解析字段类型，替换类型参数
-		fieldSchema := p.typeToSchema(field.Type())
-		schema.Properties[jsonName] = fieldSchema
-
-		if !omitempty {
-			schema.Required = append(schema.Required, jsonName)
-		}
+	// 创建实例化的 Schema (底层结构体中的类型参数已被替换为实际类型)
+	schema := p.buildStructSchema(underlying, schemaName, p.typeToSchema)
+	if schema.Description == "" {
+		schema.Description = p.namedDoc(typ.Obj().Pos(), typ.Obj().Name())
 	}
 
 	// 添加到 Components
-	p.ensureComponents()
-	if p.OpenAPI.Components.Schemas == nil {
-		p.OpenAPI.Components.Schemas = make(map[string]*model.Schema)
-	}
-	p.OpenAPI.Components.Schemas[schemaName] = schema
+	p.addComponentSchema(schemaName, schema)
 
 	return &model.Schema{Ref: refPath}
 }
@@ -667,35 +667,58 @@ func (p *Processor) primitiveTypeToSchema(typeName string) *model.Schema {
 	}
 }
 
-// parseJSONTag 解析 json tag
+// jsonOptions json tag 中名称之后的选项
+type jsonOptions struct {
+	// json:"-": 字段不参与序列化 (注意 json:"-," 表示字段名就是 "-")
+	ignore    bool
+	omitempty bool
+	// Go 1.24+: 零值时省略
+	omitzero bool
+	// 基本类型的值被编码为 JSON 字符串 (如 int64 -> "123")
+	asString bool
+}
+
+// parseJSONTag 解析 json tag，与 encoding/json 的规则一致
 // 输入: `json:"name,omitempty"`
-// 输出: ("name", true)
-func parseJSONTag(tag string) (name string, omitempty bool) {
-	// 查找 json tag
-	jsonTag := ""
-	for _, t := range strings.Split(tag, " ") {
-		t = strings.Trim(t, "`")
-		if strings.HasPrefix(t, "json:") {
-			jsonTag = strings.TrimPrefix(t, "json:")
-			jsonTag = strings.Trim(jsonTag, "\"")
-			break
-		}
+// 输出: ("name", {omitempty: true})
+// 名称不合法时返回空名称 (使用字段名)
+func parseJSONTag(tag string) (name string, opts jsonOptions) {
+	value := lookupTag(tag, "json")
+	if value == "-" {
+		opts.ignore = true
+		return "", opts
 	}
 
-	if jsonTag == "" {
-		return "", false
+	name, rest, _ := strings.Cut(value, ",")
+	if !isValidJSONName(name) {
+		name = ""
 	}
 
-	parts := strings.Split(jsonTag, ",")
-	name = parts[0]
-
-	for _, opt := range parts[1:] {
-		if opt == "omitempty" {
-			omitempty = true
+	for _, opt := range strings.Split(rest, ",") {
+		switch opt {
+		case "omitempty":
+			opts.omitempty = true
+		case "omitzero":
+			opts.omitzero = true
+		case "string":
+			opts.asString = true
 		}
 	}
 
-	return name, omitempty
+	return name, opts
+}
+
+// isValidJSONName 检查 json tag 中的名称是否合法 (encoding/json 会忽略不合法的名称)
+func isValidJSONName(name string) bool {
+	for _, c := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+			// 允许的标点符号 (不包括引号、反斜杠和逗号)
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
 }
 
 // parseDescTag 解析描述 tag
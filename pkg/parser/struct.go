@@ -0,0 +1,343 @@
+package parser
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+// 必填字段的判定方式
+const (
+	// RequiredDefault 没有 omitempty / omitzero 的非指针字段为必填 (默认)
+	RequiredDefault = "default"
+	// RequiredOmitEmpty 没有 omitempty / omitzero 的字段为必填 (包括指针字段)
+	RequiredOmitEmpty = "omitempty"
+	// RequiredValidate 只有校验规则 (validate / binding) 要求 required 的字段为必填
+	RequiredValidate = "validate"
+)
+
+// 可为 null 的字段
+const (
+	// NullablePointer 指针字段可为 null (默认)
+	NullablePointer = "pointer"
+	// NullableNone 不生成 null
+	NullableNone = "none"
+)
+
+// structField 结构体中会被 JSON 序列化的一个字段 (包括从嵌入结构体提升上来的字段)
+type structField struct {
+	// JSON 属性名
+	name string
+	// 字段对象
+	field *types.Var
+	// 原始 struct tag
+	tag string
+	// json tag 选项 (omitempty / omitzero)
+	opts jsonOptions
+	// 名称是否来自 json tag
+	tagged bool
+	// 嵌入深度，0 表示结构体自身的字段
+	depth int
+}
+
+// collectStructFields 按 encoding/json 的规则收集结构体字段
+// expandEmbedded 为 true 时展开嵌入结构体并提升其字段 (同名字段按深度和 json tag 决定胜出者)；
+// 为 false 时嵌入结构体不展开，而是通过 embeds 返回，供 allOf 模式引用
+func collectStructFields(st *types.Struct, expandEmbedded bool) (fields []structField, embeds []*types.Var) {
+	visited := make(map[*types.Struct]bool)
+
+	var walk func(st *types.Struct, depth int)
+	walk = func(st *types.Struct, depth int) {
+		// 防止嵌入自身 (如 type Node struct{ *Node }) 导致死循环
+		if visited[st] {
+			return
+		}
+		visited[st] = true
+		defer delete(visited, st)
+
+		for i := 0; i < st.NumFields(); i++ {
+			field := st.Field(i)
+			tag := st.Tag(i)
+
+			jsonName, opts := parseJSONTag(tag)
+			if opts.ignore {
+				continue
+			}
+
+			// 未指定 json 名称的嵌入结构体: 字段被提升到外层
+			// 未导出的嵌入结构体同样会提升其导出字段
+			if field.Embedded() && jsonName == "" {
+				if inner, ok := embeddedStruct(field.Type()); ok {
+					if !expandEmbedded && depth == 0 {
+						embeds = append(embeds, field)
+						continue
+					}
+					walk(inner, depth+1)
+					continue
+				}
+			}
+
+			// 跳过未导出字段
+			if !field.Exported() {
+				continue
+			}
+
+			name := jsonName
+			if name == "" {
+				name = field.Name()
+			}
+
+			fields = append(fields, structField{
+				name:   name,
+				field:  field,
+				tag:    tag,
+				opts:   opts,
+				tagged: jsonName != "",
+				depth:  depth,
+			})
+		}
+	}
+	walk(st, 0)
+
+	return dominantFields(fields), embeds
+}
+
+// dominantFields 处理同名字段的遮蔽规则 (与 encoding/json 一致)
+// - 嵌入深度最浅的字段胜出
+// - 深度相同时，唯一带 json tag 的字段胜出
+// - 否则视为冲突，所有同名字段都被忽略
+func dominantFields(fields []structField) []structField {
+	byName := make(map[string][]int)
+	for i, f := range fields {
+		byName[f.name] = append(byName[f.name], i)
+	}
+
+	keep := make([]bool, len(fields))
+	for _, idxs := range byName {
+		minDepth := fields[idxs[0]].depth
+		for _, i := range idxs[1:] {
+			minDepth = min(minDepth, fields[i].depth)
+		}
+
+		var shallow, tagged []int
+		for _, i := range idxs {
+			if fields[i].depth == minDepth {
+				shallow = append(shallow, i)
+				if fields[i].tagged {
+					tagged = append(tagged, i)
+				}
+			}
+		}
+
+		switch {
+		case len(shallow) == 1:
+			keep[shallow[0]] = true
+		case len(tagged) == 1:
+			keep[tagged[0]] = true
+		}
+	}
+
+	var result []structField
+	for i, f := range fields {
+		if keep[i] {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// embeddedStruct 获取嵌入字段对应的结构体 (支持指针嵌入)
+func embeddedStruct(t types.Type) (*types.Struct, bool) {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	st, ok := t.Underlying().(*types.Struct)
+	return st, ok
+}
+
+// buildStructSchema 根据结构体字段构建 object Schema
+// fieldSchema 负责把字段类型转换为 Schema (普通结构体与泛型实例化使用不同的转换方式)
+func (p *Processor) buildStructSchema(st *types.Struct, name string, fieldSchema func(types.Type) *model.Schema) *model.Schema {
+	fields, embeds := collectStructFields(st, !p.opts.EmbedAllOf)
+
+	// Title 固定为类型名，代码生成工具据此命名模型，文档注释只写入 Description
+	schema := &model.Schema{
+		Type:       "object",
+		Title:      name,
+		Properties: model.NewOrderedMap[*model.Schema](),
+	}
+
+	for _, f := range fields {
+		// 解析字段类型
+		propSchema := fieldSchema(f.field.Type())
+
+		// 校验规则 (validate / binding tag) 映射为约束
+		validated := applyValidation(propSchema, f.field.Type(), f.tag)
+
+		// json:",string" 基本类型的值被编码为字符串
+		if f.opts.asString {
+			propSchema = p.quotedSchema(propSchema, f)
+		}
+
+		// 指针字段可为 null
+		_, isPointer := f.field.Type().(*types.Pointer)
+		if isPointer && p.opts.Nullable != NullableNone {
+			propSchema = nullableSchema(propSchema)
+		}
+
+		// 添加字段描述 (desc tag 优先，其次是字段注释)
+		if desc := parseDescTag(f.tag); desc != "" {
+			propSchema.Description = desc
+		} else if doc := p.namedDoc(f.field.Pos(), f.field.Name()); doc != "" {
+			propSchema.Description = doc
+		}
+
+		schema.Properties.Set(f.name, propSchema)
+
+		if validated || p.fieldRequired(f, isPointer) {
+			schema.Required = append(schema.Required, f.name)
+		}
+	}
+
+	if len(embeds) == 0 {
+		return schema
+	}
+
+	// allOf 模式: 嵌入的基础模型通过 $ref 复用，自身字段作为最后一个子 Schema
+	composed := &model.Schema{Title: name}
+	for _, embed := range embeds {
+		composed.AllOf = append(composed.AllOf, p.embeddedSchema(embed.Type(), fieldSchema))
+	}
+	if schema.Properties.Len() > 0 {
+		schema.Title = ""
+		composed.AllOf = append(composed.AllOf, schema)
+	}
+	return composed
+}
+
+// quotedSchema 将 json:",string" 字段的 Schema 转换为字符串
+// encoding/json 只对字符串、数值、布尔类型 (及其指针) 生效，其他类型保持不变
+// 整数保留 format 以表明其取值范围: {type: string, format: int64}
+// default、example、enum 转换为字符串；数值范围 (minimum、maximum、multipleOf 等) 无法约束字符串，给出警告后去掉
+func (p *Processor) quotedSchema(schema *model.Schema, f structField) *model.Schema {
+	var pattern string
+	switch schema.Type {
+	case "integer":
+		pattern = `^-?[0-9]+$`
+	case "number":
+		pattern = `^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
+	case "boolean":
+		pattern = `^(true|false)$`
+	default:
+		// 字符串被再次 JSON 编码后仍是字符串，其他类型不受影响
+		return schema
+	}
+
+	quoted := *schema
+	quoted.Type = "string"
+	quoted.Pattern = pattern
+	quoted.Enum = nil
+	for _, v := range schema.Enum {
+		quoted.Enum = append(quoted.Enum, fmt.Sprint(v))
+	}
+	if len(quoted.Enum) > 0 {
+		quoted.Pattern = ""
+	}
+	if schema.Default != nil {
+		quoted.Default = fmt.Sprint(schema.Default)
+	}
+	if schema.Example != nil {
+		quoted.Example = fmt.Sprint(schema.Example)
+	}
+	quoted.Examples = nil
+	for _, v := range schema.Examples {
+		quoted.Examples = append(quoted.Examples, fmt.Sprint(v))
+	}
+
+	var dropped []string
+	for _, bound := range []struct {
+		name  string
+		value any
+	}{
+		{"minimum", schema.Minimum},
+		{"maximum", schema.Maximum},
+		{"exclusiveMinimum", schema.ExclusiveMinimum},
+		{"exclusiveMaximum", schema.ExclusiveMaximum},
+		{"multipleOf", schema.MultipleOf},
+	} {
+		if bound.value != nil && bound.value != 0.0 {
+			dropped = append(dropped, bound.name)
+		}
+	}
+	if len(dropped) > 0 {
+		p.report(f.field.Pos(), SeverityWarning, fmt.Sprintf(
+			"字段 %s 使用 json:\",string\" 编码为字符串，%s 无法约束字符串，已忽略", f.name, strings.Join(dropped, "、")))
+	}
+	quoted.Minimum, quoted.Maximum = nil, nil
+	quoted.ExclusiveMinimum, quoted.ExclusiveMaximum = nil, nil
+	quoted.MultipleOf = 0
+	return &quoted
+}
+
+// fieldRequired 按 Options.Required 判断字段是否必填 (不含校验规则中的 required)
+func (p *Processor) fieldRequired(f structField, isPointer bool) bool {
+	omitted := f.opts.omitempty || f.opts.omitzero
+	switch p.opts.Required {
+	case RequiredValidate:
+		return false
+	case RequiredOmitEmpty:
+		return !omitted
+	default:
+		return !omitted && !isPointer
+	}
+}
+
+// embeddedSchema 获取嵌入结构体在 allOf 中的 Schema
+// 普通命名结构体注册到 Components 并返回 $ref，泛型实例交由 fieldSchema 处理
+func (p *Processor) embeddedSchema(t types.Type, fieldSchema func(types.Type) *model.Schema) *model.Schema {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	if named, ok := t.(*types.Named); ok && named.TypeArgs().Len() == 0 {
+		return p.namedStructRef(named)
+	}
+	return fieldSchema(t)
+}
+
+// mapKeySchema 获取 map 键对应的 propertyNames 约束 (与 encoding/json 的键编码方式一致)
+// - 字符串类型: 带枚举常量时约束为枚举值，否则不约束
+// - 实现 encoding.TextMarshaler: 使用该类型的 Schema (如 uuid.UUID -> format: uuid)
+// - 整数类型: 十进制数字字符串
+func (p *Processor) mapKeySchema(key types.Type) *model.Schema {
+	basic, _ := key.Underlying().(*types.Basic)
+	isString := basic != nil && basic.Info()&types.IsString != 0
+
+	if named, ok := types.Unalias(key).(*types.Named); ok {
+		if isString {
+			if schema := p.enumSchema(named); schema != nil {
+				return &model.Schema{Enum: schema.Enum}
+			}
+			return nil
+		}
+		if hasMarshalMethod(named, "MarshalText") {
+			schema := p.declaredTypeSchema(named)
+			if schema != nil && schema.Type == "string" && (schema.Format != "" || schema.Pattern != "") {
+				return &model.Schema{Format: schema.Format, Pattern: schema.Pattern}
+			}
+			return nil
+		}
+	}
+
+	if basic == nil || isString {
+		return nil
+	}
+	switch {
+	case basic.Info()&types.IsUnsigned != 0:
+		return &model.Schema{Pattern: `^[0-9]+$`}
+	case basic.Info()&types.IsInteger != 0:
+		return &model.Schema{Pattern: `^-?[0-9]+$`}
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package parser
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+// checkSource 对源码进行类型检查，源码中不能引用其他包
+func checkSource(t *testing.T, src string) *types.Package {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "src.go", "package p\n"+src, 0)
+	if err != nil {
+		t.Fatalf("ParseFile() error = %v", err)
+	}
+	pkg, err := new(types.Config).Check("example.com/p", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	return pkg
+}
+
+// lookupStruct 获取源码中定义的结构体
+func lookupStruct(t *testing.T, pkg *types.Package, name string) *types.Struct {
+	t.Helper()
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		t.Fatalf("类型 %s 不存在", name)
+	}
+	st, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		t.Fatalf("类型 %s 不是结构体", name)
+	}
+	return st
+}
+
+func TestCollectStructFields(t *testing.T) {
+	tests := []struct {
+		name       string
+		src        string
+		expand     bool
+		wantFields []string
+		wantEmbeds []string
+	}{
+		{
+			name:       "json tag 与忽略字段",
+			src:        "type T struct { A int `json:\"a\"`; B int `json:\"-\"`; C int `json:\"-,\"`; d int; E int }",
+			expand:     true,
+			wantFields: []string{"a", "-", "E"},
+		},
+		{
+			name:       "嵌入结构体的字段被提升",
+			src:        "type Base struct { ID int `json:\"id\"` }; type T struct { Base; Name string `json:\"name\"` }",
+			expand:     true,
+			wantFields: []string{"id", "name"},
+		},
+		{
+			name:       "浅层字段遮蔽嵌入字段",
+			src:        "type Base struct { Name string `json:\"name\"` }; type T struct { Base; Name string `json:\"name\"` }",
+			expand:     true,
+			wantFields: []string{"name"},
+		},
+		{
+			name:       "同深度时带 tag 的字段胜出",
+			src:        "type A struct { Name string `json:\"Name\"` }; type B struct { Name string }; type T struct { A; B }",
+			expand:     true,
+			wantFields: []string{"Name"},
+		},
+		{
+			name:       "同深度冲突的字段全部忽略",
+			src:        "type A struct { X int }; type B struct { X int }; type T struct { A; B; Y int }",
+			expand:     true,
+			wantFields: []string{"Y"},
+		},
+		{
+			name:       "带 json 名称的嵌入结构体不展开",
+			src:        "type Base struct { ID int }; type T struct { Base `json:\"base\"` }",
+			expand:     true,
+			wantFields: []string{"base"},
+		},
+		{
+			name:       "未导出的嵌入结构体提升导出字段",
+			src:        "type inner struct { Secret string `json:\"secret\"`; hidden int }; type T struct { inner }",
+			expand:     true,
+			wantFields: []string{"secret"},
+		},
+		{
+			name:       "指针嵌入自身不会死循环",
+			src:        "type T struct { *T; Q string `json:\"q\"` }",
+			expand:     true,
+			wantFields: []string{"q"},
+		},
+		{
+			name:       "allOf 模式不展开嵌入结构体",
+			src:        "type Base struct { ID int }; type T struct { Base; Name string }",
+			expand:     false,
+			wantFields: []string{"Name"},
+			wantEmbeds: []string{"Base"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := lookupStruct(t, checkSource(t, tt.src), "T")
+			fields, embeds := collectStructFields(st, tt.expand)
+
+			var gotFields, gotEmbeds []string
+			for _, f := range fields {
+				gotFields = append(gotFields, f.name)
+			}
+			for _, e := range embeds {
+				gotEmbeds = append(gotEmbeds, e.Name())
+			}
+			if !slices.Equal(gotFields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v", gotFields, tt.wantFields)
+			}
+			if !slices.Equal(gotEmbeds, tt.wantEmbeds) {
+				t.Errorf("embeds = %v, want %v", gotEmbeds, tt.wantEmbeds)
+			}
+		})
+	}
+}
+
+func TestNamedStructFields(t *testing.T) {
+	openapi, _ := parseApp(t, Options{})
+	customer := componentSchema(t, openapi, "Customer")
+
+	// 字段中的命名结构体内联，不注册到 Components
+	home, _ := customer.Properties.Get("home")
+	if home == nil || home.Ref != "" || home.Type != "object" {
+		t.Fatalf("home = %+v, want 内联的 object", home)
+	}
+	if _, ok := home.Properties.Get("city"); !ok {
+		t.Errorf("home 缺少属性 city")
+	}
+	if _, ok := openapi.Components.Schemas["Address"]; ok {
+		t.Errorf("内联的 Address 不应注册到 Components")
+	}
+
+	// 引用自身的结构体注册到 Components，避免无限递归
+	root, _ := customer.Properties.Get("root")
+	if root == nil || root.Ref != "" {
+		t.Fatalf("root = %+v, want 内联的 object", root)
+	}
+	children, _ := root.Properties.Get("children")
+	if children == nil || children.Items == nil || children.Items.Ref != "#/components/schemas/Node" {
+		t.Errorf("children = %+v, want items 引用 Node", children)
+	}
+	componentSchema(t, openapi, "Node")
+}
+
+func TestRequiredNullable(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         Options
+		wantRequired []string
+		wantNullable bool
+	}{
+		{"默认", Options{}, []string{"email", "alias"}, true},
+		{"RequiredOmitEmpty", Options{Required: RequiredOmitEmpty}, []string{"email", "phone", "owner", "alias"}, true},
+		{"RequiredValidate", Options{Required: RequiredValidate}, []string{"alias"}, true},
+		{"NullableNone", Options{Nullable: NullableNone}, []string{"email", "alias"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			openapi, _ := parseApp(t, tt.opts)
+			contact := componentSchema(t, openapi, "Contact")
+			if !slices.Equal(contact.Required, tt.wantRequired) {
+				t.Errorf("required = %v, want %v", contact.Required, tt.wantRequired)
+			}
+
+			phone, _ := contact.Properties.Get("phone")
+			owner, _ := contact.Properties.Get("owner")
+			wantPhone, wantOwner := any("string"), 0
+			if tt.wantNullable {
+				wantPhone, wantOwner = []string{"string", "null"}, 2
+			}
+			if !reflect.DeepEqual(phone.Type, wantPhone) {
+				t.Errorf("phone 的类型 = %#v, want %#v", phone.Type, wantPhone)
+			}
+			// $ref 通过 anyOf 加上 null
+			if len(owner.AnyOf) != wantOwner {
+				t.Errorf("owner = %+v, want %d 个 anyOf", owner, wantOwner)
+			}
+		})
+	}
+}
+
+func TestJSONEncodingOptions(t *testing.T) {
+	openapi, diags := parseApp(t, Options{})
+	encoded := componentSchema(t, openapi, "Encoded")
+
+	tests := []struct {
+		name  string
+		field string
+		want  *model.Schema
+	}{
+		{"未导出的嵌入结构体", "secret", &model.Schema{Type: "string"}},
+		{",string 整数", "id", &model.Schema{Type: "string", Format: "int64", Pattern: `^-?[0-9]+$`}},
+		{",string 浮点数", "ratio", &model.Schema{Type: "string", Format: "double", Pattern: `^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`}},
+		{",string 布尔值", "ok", &model.Schema{Type: "string", Pattern: `^(true|false)$`}},
+		// 数值范围无法约束字符串，去掉并给出警告
+		{",string 带数值范围", "count", &model.Schema{Type: "string", Format: "int64", Pattern: `^-?[0-9]+$`}},
+		{",string 枚举", "lvl", &model.Schema{
+			Description: "等级",
+			Type:        "string",
+			Format:      "int32",
+			Enum:        []any{"1", "2"},
+			Extensions:  map[string]any{"x-enum-varnames": []string{"LevelLow", "LevelHigh"}},
+		}},
+		{"名为 - 的字段", "-", &model.Schema{Type: "integer", Format: "int32"}},
+		{"整数键", "byId", mapSchema(&model.Schema{Pattern: `^-?[0-9]+$`}, "string")},
+		{"整数枚举键", "byLevel", mapSchema(&model.Schema{Pattern: `^-?[0-9]+$`}, "string")},
+		{"没有格式的 TextMarshaler 键", "byCode", mapSchema(nil, "string")},
+		{"带格式的 TextMarshaler 键", "byUuid", mapSchema(&model.Schema{Format: "uuid"}, "string")},
+		{"字符串枚举键", "byState", mapSchema(&model.Schema{Enum: []any{"active", "disabled"}}, "integer")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prop, ok := encoded.Properties.Get(tt.field)
+			if !ok {
+				t.Fatalf("Encoded 缺少属性 %s", tt.field)
+			}
+			got, _ := json.Marshal(prop)
+			want, _ := json.Marshal(tt.want)
+			if string(got) != string(want) {
+				t.Errorf("%s = %s, want %s", tt.field, got, want)
+			}
+		})
+	}
+
+	if _, ok := encoded.Properties.Get("Skip"); ok {
+		t.Errorf(`json:"-" 的字段不应输出`)
+	}
+	if !hasDiagnostic(diags, `字段 count 使用 json:",string" 编码为字符串，minimum、maximum 无法约束字符串`) {
+		t.Errorf("缺少数值范围被忽略的警告: %v", diags)
+	}
+}
+
+func TestQuotedSchema(t *testing.T) {
+	p := newProcessor(token.NewFileSet(), Options{})
+	field := structField{field: types.NewField(token.NoPos, nil, "N", types.Typ[types.Int], false), name: "n"}
+
+	schema := &model.Schema{Type: "integer", Default: int64(5), Example: int64(6), Examples: []any{int64(7)}, MultipleOf: 5}
+	got := p.quotedSchema(schema, field)
+	want := &model.Schema{Type: "string", Pattern: `^-?[0-9]+$`, Default: "5", Example: "6", Examples: []any{"7"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("quotedSchema() = %+v, want %+v", got, want)
+	}
+	if !hasDiagnostic(p.Diagnostics, "multipleOf 无法约束字符串") {
+		t.Errorf("缺少 multipleOf 被忽略的警告: %v", p.Diagnostics)
+	}
+}
+
+// mapSchema 键名受 keys 约束、值为 valueType 的 map
+func mapSchema(keys *model.Schema, valueType string) *model.Schema {
+	value := &model.Schema{Type: valueType}
+	if valueType == "integer" {
+		value.Format = "int32"
+	}
+	return &model.Schema{Type: "object", AdditionalProperties: value, PropertyNames: keys}
+}
@@ -0,0 +1,46 @@
+package chiroutes
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// @Summary list users
+func ListUsers(w http.ResponseWriter, r *http.Request) {}
+
+// @Summary get user
+func GetUser(w http.ResponseWriter, r *http.Request) {}
+
+// @Summary stats
+func Stats(w http.ResponseWriter, r *http.Request) {}
+
+// @Summary delete file
+func DeleteFile(w http.ResponseWriter, r *http.Request) {}
+
+// @Summary health
+func Health(w http.ResponseWriter, r *http.Request) {}
+
+// @Summary reports
+func Reports(w http.ResponseWriter, r *http.Request) {}
+
+func Routes() http.Handler {
+	r := chi.NewRouter()
+	r.Put("/", Health)
+	r.Get("/reports/", Reports)
+	r.Route("/users", func(r chi.Router) {
+		r.Get("/", ListUsers)
+		r.Get("/{id:[0-9]{1,8}}", GetUser)
+	})
+	r.Mount("/admin", adminRouter())
+	r.Group(func(r chi.Router) {
+		r.Method("DELETE", "/files/*", http.HandlerFunc(DeleteFile))
+	})
+	return r
+}
+
+func adminRouter() http.Handler {
+	r := chi.NewRouter()
+	r.Get("/stats", Stats)
+	return r
+}
@@ -0,0 +1,10 @@
+package order
+
+// @Summary list orders
+// @Tags orders
+// @Router /conflict/orders [get]
+func List() {}
+
+// @Summary update user again
+// @Router /conflict/users/{id} [put]
+func Update() {}
@@ -0,0 +1,10 @@
+package user
+
+// @Summary list users
+// @Tags users
+// @Router /conflict/users [get]
+func List() {}
+
+// @Summary update user
+// @Router /conflict/users/{uid} [put]
+func Update() {}
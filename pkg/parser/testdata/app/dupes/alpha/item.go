@@ -0,0 +1,6 @@
+package alpha
+
+// Item alpha 中的条目
+type Item struct {
+	Name string `json:"name"`
+}
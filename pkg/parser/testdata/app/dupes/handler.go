@@ -0,0 +1,18 @@
+package dupes
+
+import (
+	"example.com/app/dupes/alpha"
+	"example.com/app/dupes/zeta"
+)
+
+// 注解中引用的包需要被导入
+var (
+	_ alpha.Item
+	_ zeta.Item
+)
+
+// @Summary get items
+// @Success 200 {object} zeta.Item
+// @Failure 400 {object} alpha.Item
+// @Router /dupes/items [get]
+func GetItems() {}
@@ -0,0 +1,6 @@
+package zeta
+
+// Item zeta 中的条目
+type Item struct {
+	Code string `json:"code"`
+}
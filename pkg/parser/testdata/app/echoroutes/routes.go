@@ -0,0 +1,23 @@
+package echoroutes
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary get item
+func GetItem(c echo.Context) error { return nil }
+
+// @Summary create item
+func CreateItem(c echo.Context) error { return nil }
+
+// @Summary update item
+func UpdateItem(c echo.Context) error { return nil }
+
+func Register(e *echo.Echo) {
+	g := e.Group("/v2")
+	g.GET("/items/:id", GetItem)
+	g.Group("/admin").Add("POST", "/items", CreateItem)
+	e.Match([]string{"PUT", http.MethodPatch}, "/items/:id", UpdateItem)
+}
@@ -0,0 +1,15 @@
+package filter
+
+// @Summary public
+// @Tags users
+// @Router /public [get]
+func Public() {}
+
+// @Summary internal
+// @Tags users, internal
+// @Router /internal [get]
+func Internal() {}
+
+// @Summary untagged
+// @Router /untagged [get]
+func Untagged() {}
@@ -0,0 +1,23 @@
+package ginroutes
+
+import "github.com/gin-gonic/gin"
+
+type Handler struct{}
+
+// @Summary get account
+func (h *Handler) GetAccount(c *gin.Context) {}
+
+// @Summary files
+func (h *Handler) Files(c *gin.Context) {}
+
+// @Summary ping
+func Ping(c *gin.Context) {}
+
+func Register(r *gin.Engine) {
+	h := &Handler{}
+	api := r.Group("/api")
+	v1 := api.Group("/v1/")
+	v1.GET("/accounts/:id", h.GetAccount)
+	v1.GET("/files/*path", h.Files)
+	r.Handle("POST", "/ping", Ping)
+}
@@ -0,0 +1,15 @@
+module example.com/app
+
+go 1.22
+
+require (
+	github.com/gin-gonic/gin v1.0.0
+	github.com/go-chi/chi/v5 v5.0.0
+	github.com/labstack/echo/v4 v4.0.0
+)
+
+replace github.com/gin-gonic/gin => ../fake/gin
+
+replace github.com/go-chi/chi/v5 => ../fake/chi
+
+replace github.com/labstack/echo/v4 => ../fake/echo
@@ -0,0 +1,37 @@
+package infer
+
+import "github.com/gin-gonic/gin"
+
+type ListQuery struct {
+	Page    int      `form:"page,default=1" binding:"min=1"`
+	Keyword string   `form:"q"`
+	Tags    []string `form:"tags"`
+}
+
+type UserURI struct {
+	ID int64 `uri:"id" binding:"required"`
+}
+
+type UpdateReq struct {
+	Name string `json:"name"`
+}
+
+// @Summary search
+// @Param q query string true "keyword"
+// @Router /search [get]
+func Search(c *gin.Context) {
+	var q ListQuery
+	_ = c.ShouldBindQuery(&q)
+	_ = c.GetHeader("X-Trace")
+}
+
+// @Summary update
+// @Router /infer/users/{id} [put]
+func UpdateUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var uri UserURI
+		_ = c.ShouldBindUri(&uri)
+		var req UpdateReq
+		_ = c.ShouldBindJSON(&req)
+	}
+}
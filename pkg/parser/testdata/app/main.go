@@ -0,0 +1,9 @@
+package main
+
+// @OpenAPI 3.2.0
+// @Title App
+// @Version 1.0
+// @Tag.Name users
+// @Tag.Name orders
+// @Tag.Name internal
+func main() {}
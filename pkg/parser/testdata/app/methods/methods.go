@@ -0,0 +1,21 @@
+package methods
+
+// @Summary typo
+// @Router /typo [gettt]
+func Typo() {}
+
+// @Summary invalid
+// @Router /invalid [ge(t]
+func Invalid() {}
+
+// @Summary purge
+// @Router /cache [purge]
+// @Router /cache [copy]
+func Purge() {}
+
+// @Summary things
+// @Param id path int true "ID"
+// @Param q query string false "q"
+// @Router /things/{id} [get]
+// @Router /v3/things [get]
+func Things() {}
@@ -0,0 +1,10 @@
+package models
+
+// @Summary list sorted
+// @Param sort query string false "sort order" enums(asc,desc) default=asc
+// @Param limit query int false "limit" minimum(1) maximum=100 example(20)
+// @Param ids query []int false "ids" style(pipeDelimited) explode(false)
+// @Param old header string false "old header" deprecated(true)
+// @Param bad query int false "bad" minimum(abc) style(zigzag)
+// @Router /models/sorted [get]
+func ListSorted() {}
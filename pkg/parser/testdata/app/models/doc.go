@@ -0,0 +1,15 @@
+package models
+
+// Profile 用户资料
+type Profile struct {
+	// Nickname 昵称
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar"`          // 头像地址
+	Bio      string `json:"bio" desc:"个人简介"` // desc tag 优先于注释
+	Age      int    `json:"age"`
+}
+
+// @Summary get profile
+// @Success 200 {object} Profile
+// @Router /models/profile [get]
+func GetProfile() {}
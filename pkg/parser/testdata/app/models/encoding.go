@@ -0,0 +1,40 @@
+package models
+
+// Level 等级
+type Level int
+
+const (
+	LevelLow  Level = 1
+	LevelHigh Level = 2
+)
+
+// UUID 实现 encoding.TextMarshaler
+// @Schema string uuid
+type UUID [16]byte
+
+func (u UUID) MarshalText() ([]byte, error) { return nil, nil }
+
+type inner struct {
+	Secret string `json:"secret"`
+}
+
+type Encoded struct {
+	inner
+	ID      int64            `json:"id,string"`
+	Ratio   float64          `json:"ratio,string"`
+	OK      bool             `json:"ok,string"`
+	Count   int64            `json:"count,string" validate:"min=1,max=100"`
+	Lvl     Level            `json:"lvl,string"`
+	Dash    int              `json:"-,"`
+	Skip    int              `json:"-"`
+	ByID    map[int]string   `json:"byId"`
+	ByLevel map[Level]string `json:"byLevel"`
+	ByCode  map[Code]string  `json:"byCode"`
+	ByUUID  map[UUID]string  `json:"byUuid"`
+	ByState map[Status]int   `json:"byState"`
+}
+
+// @Summary get encoded
+// @Success 200 {object} Encoded
+// @Router /models/encoded [get]
+func GetEncoded() {}
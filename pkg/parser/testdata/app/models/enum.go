@@ -0,0 +1,21 @@
+package models
+
+import "net/http"
+
+// Status 状态
+type Status string
+
+const (
+	StatusActive   Status = "active"   // 正常
+	StatusDisabled Status = "disabled" // 已禁用
+)
+
+type Account struct {
+	Status Status        `json:"status"`
+	Site   http.SameSite `json:"site"`
+}
+
+// @Summary get account
+// @Success 200 {object} Account
+// @Router /models/account [get]
+func GetAccount() {}
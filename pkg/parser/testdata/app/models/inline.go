@@ -0,0 +1,23 @@
+package models
+
+// Address 地址
+type Address struct {
+	City string `json:"city"`
+}
+
+// Node 树节点
+type Node struct {
+	Name     string `json:"name"`
+	Children []Node `json:"children"`
+}
+
+// Customer 客户
+type Customer struct {
+	Home Address `json:"home"`
+	Root Node    `json:"root"`
+}
+
+// @Summary get customer
+// @Success 200 {object} Customer
+// @Router /models/customer [get]
+func GetCustomer() {}
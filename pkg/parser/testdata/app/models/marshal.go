@@ -0,0 +1,36 @@
+package models
+
+// Code 实现 encoding.TextMarshaler，序列化为字符串
+type Code struct{ v int }
+
+func (c Code) MarshalText() ([]byte, error) { return nil, nil }
+
+// Blob 实现 json.Marshaler，无法推断结构
+type Blob struct{ A int }
+
+func (b Blob) MarshalJSON() ([]byte, error) { return nil, nil }
+
+// Amount 金额
+// @Schema string decimal
+type Amount struct{ cents int64 }
+
+// Wrapped 嵌入 Code，提升 MarshalText 方法
+type Wrapped struct{ Code }
+
+// Token 指针接收者实现 MarshalText
+type Token struct{ v string }
+
+func (t *Token) MarshalText() ([]byte, error) { return nil, nil }
+
+type Marshalers struct {
+	Code    Code    `json:"code"`
+	Blob    Blob    `json:"blob"`
+	Amount  Amount  `json:"amount"`
+	Wrapped Wrapped `json:"wrapped"`
+	Token   Token   `json:"token"`
+}
+
+// @Summary get marshalers
+// @Success 200 {object} Marshalers
+// @Router /models/marshalers [get]
+func GetMarshalers() {}
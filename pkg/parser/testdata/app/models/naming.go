@@ -0,0 +1,10 @@
+package models
+
+type Page[T any] struct {
+	Items []T `json:"items"`
+}
+
+// @Summary list profiles
+// @Success 200 {object} Page[Profile]
+// @Router /models/profiles [get]
+func ListProfiles() {}
@@ -0,0 +1,15 @@
+package models
+
+type Contact struct {
+	Email string   `json:"email"`
+	Phone *string  `json:"phone"`
+	Nick  string   `json:"nick,omitempty"`
+	Score int      `json:"score,omitzero"`
+	Owner *Profile `json:"owner"`
+	Alias *string  `json:"alias" validate:"required"`
+}
+
+// @Summary get contact
+// @Success 200 {object} Contact
+// @Router /models/contact [get]
+func GetContact() {}
@@ -0,0 +1,34 @@
+package models
+
+// Paging 分页参数
+type Paging struct {
+	Page int `form:"page,default=1"`
+}
+
+// Filter 列表查询条件
+type Filter struct {
+	Paging
+	// Keyword 搜索关键字
+	Keyword string   `form:"keyword" binding:"required"`
+	Tags    []string `query:"tags"`
+	Size    int      `form:"size" desc:"每页条数"`
+	Ignored string   `form:"-"`
+	hidden  string
+}
+
+// Loop 嵌入自身，展开参数时不应无限递归
+type Loop struct {
+	*Loop
+	Q string `form:"q"`
+}
+
+// @Summary list filtered
+// @Param f query Filter false "filter"
+// @Param size query int false "page size"
+// @Router /models/filtered [get]
+func ListFiltered() {}
+
+// @Summary get loop
+// @Param l query Loop false "loop"
+// @Router /models/loop [get]
+func GetLoop() {}
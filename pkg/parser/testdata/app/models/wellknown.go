@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Money 使用 TypeOverrides 映射
+type Money struct{ cents int }
+
+type Wallet struct {
+	Balance *Money    `json:"balance"`
+	Created time.Time `json:"created"`
+	Raw     []byte    `json:"raw"`
+}
+
+// @Summary get wallet
+// @Success 200 {object} Wallet
+// @Router /models/wallet [get]
+func GetWallet() {}
@@ -0,0 +1,29 @@
+package muxroutes
+
+import "net/http"
+
+// @Summary index
+func Index(w http.ResponseWriter, r *http.Request) {}
+
+// @Summary get member
+func GetMember(w http.ResponseWriter, r *http.Request) {}
+
+// @Summary files
+func Download(w http.ResponseWriter, r *http.Request) {}
+
+// @Summary login
+func Login(w http.ResponseWriter, r *http.Request) {}
+
+// @Summary upload
+// @Router /uploads [post]
+func Upload(w http.ResponseWriter, r *http.Request) {}
+
+func Register() {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", Index)
+	mux.HandleFunc("GET /members/{id}", GetMember)
+	mux.Handle("/downloads/{path...}", http.HandlerFunc(Download))
+	http.HandleFunc("POST /login", Login)
+	// 没有方法的模式匹配所有方法，@Router 中的任何方法都与其一致
+	mux.HandleFunc("/uploads", Upload)
+}
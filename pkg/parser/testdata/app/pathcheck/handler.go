@@ -0,0 +1,16 @@
+package pathcheck
+
+// @Summary missing path param
+// @Param q query string false "q"
+// @Router /pathcheck/users/{id} [get]
+func Missing() {}
+
+// @Summary extra path param
+// @Param oid path int true "order ID"
+// @Router /pathcheck/orders [get]
+func Extra() {}
+
+// @Summary repeated path param
+// @Param id path int true "ID"
+// @Router /pathcheck/{id}/copy/{id} [post]
+func Repeated() {}
@@ -0,0 +1,25 @@
+// Package chi 测试用的 chi 替身，只声明路由发现用到的 API
+package chi
+
+import "net/http"
+
+type Router interface {
+	http.Handler
+	Use(m ...func(http.Handler) http.Handler)
+	With(m ...func(http.Handler) http.Handler) Router
+	Group(fn func(r Router)) Router
+	Route(pattern string, fn func(r Router)) Router
+	Mount(pattern string, h http.Handler)
+	Handle(pattern string, h http.Handler)
+	HandleFunc(pattern string, h http.HandlerFunc)
+	Method(method, pattern string, h http.Handler)
+	MethodFunc(method, pattern string, h http.HandlerFunc)
+	Get(pattern string, h http.HandlerFunc)
+	Post(pattern string, h http.HandlerFunc)
+	Put(pattern string, h http.HandlerFunc)
+	Delete(pattern string, h http.HandlerFunc)
+}
+
+type Mux struct{ Router }
+
+func NewRouter() *Mux { return &Mux{} }
@@ -0,0 +1,18 @@
+// Package echo 测试用的 echo 替身，只声明路由发现用到的 API
+package echo
+
+type Context interface{ Param(string) string }
+type HandlerFunc func(c Context) error
+type MiddlewareFunc func(HandlerFunc) HandlerFunc
+type Echo struct{}
+type Group struct{}
+
+func New() *Echo                                                                        { return &Echo{} }
+func (e *Echo) GET(path string, h HandlerFunc, m ...MiddlewareFunc)                     {}
+func (e *Echo) POST(path string, h HandlerFunc, m ...MiddlewareFunc)                    {}
+func (e *Echo) Any(path string, h HandlerFunc, m ...MiddlewareFunc)                     {}
+func (e *Echo) Match(methods []string, path string, h HandlerFunc, m ...MiddlewareFunc) {}
+func (e *Echo) Group(prefix string, m ...MiddlewareFunc) *Group                         { return &Group{} }
+func (g *Group) GET(path string, h HandlerFunc, m ...MiddlewareFunc)                    {}
+func (g *Group) Add(method, path string, h HandlerFunc, m ...MiddlewareFunc)            {}
+func (g *Group) Group(prefix string, m ...MiddlewareFunc) *Group                        { return &Group{} }
@@ -0,0 +1,52 @@
+// Package gin 测试用的 gin 替身，只声明路由发现与参数推断用到的 API
+package gin
+
+type Context struct{}
+
+func (c *Context) Param(key string) string             { return "" }
+func (c *Context) Query(key string) string             { return "" }
+func (c *Context) DefaultQuery(key, def string) string { return "" }
+func (c *Context) GetHeader(key string) string         { return "" }
+func (c *Context) PostForm(key string) string          { return "" }
+func (c *Context) ShouldBindJSON(obj any) error        { return nil }
+func (c *Context) ShouldBindQuery(obj any) error       { return nil }
+func (c *Context) ShouldBindUri(obj any) error         { return nil }
+func (c *Context) ShouldBindHeader(obj any) error      { return nil }
+func (c *Context) ShouldBind(obj any) error            { return nil }
+func (c *Context) BindJSON(obj any) error              { return nil }
+func (c *Context) Bind(obj any) error                  { return nil }
+func (c *Context) BindQuery(obj any) error             { return nil }
+func (c *Context) JSON(code int, obj any)              {}
+
+type HandlerFunc func(*Context)
+
+type IRoutes interface {
+	Handle(string, string, ...HandlerFunc) IRoutes
+	GET(string, ...HandlerFunc) IRoutes
+	POST(string, ...HandlerFunc) IRoutes
+}
+
+type IRouter interface {
+	IRoutes
+	Group(string, ...HandlerFunc) *RouterGroup
+}
+
+type RouterGroup struct{}
+
+func (g *RouterGroup) Group(p string, h ...HandlerFunc) *RouterGroup { return g }
+func (g *RouterGroup) Handle(m, p string, h ...HandlerFunc) IRoutes  { return g }
+func (g *RouterGroup) GET(p string, h ...HandlerFunc) IRoutes        { return g }
+func (g *RouterGroup) POST(p string, h ...HandlerFunc) IRoutes       { return g }
+func (g *RouterGroup) PUT(p string, h ...HandlerFunc) IRoutes        { return g }
+func (g *RouterGroup) DELETE(p string, h ...HandlerFunc) IRoutes     { return g }
+func (g *RouterGroup) PATCH(p string, h ...HandlerFunc) IRoutes      { return g }
+func (g *RouterGroup) Any(p string, h ...HandlerFunc) IRoutes        { return g }
+
+type Engine struct{ RouterGroup }
+
+func New() *Engine                         { return &Engine{} }
+func Default() *Engine                     { return &Engine{} }
+func (e *Engine) Run(addr ...string) error { return nil }
+
+func (c *Context) QueryArray(key string) []string     { return nil }
+func (c *Context) Cookie(name string) (string, error) { return "", nil }
@@ -0,0 +1,8 @@
+// Package api 只包含接口注解的 package 注释不是全局注释的来源
+//
+// @Deprecated use v2
+package api
+
+// @Summary ping
+// @Router /ping [get]
+func Ping() {}
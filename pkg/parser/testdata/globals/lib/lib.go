@@ -0,0 +1,5 @@
+// Package lib 带有全局注解的 package 注释优先级低于 main 函数
+//
+// @Title Lib
+// @Version 2.0
+package lib
@@ -0,0 +1,6 @@
+package main
+
+// @OpenAPI 3.2.0
+// @Title Main
+// @Version 1.0
+func main() {}
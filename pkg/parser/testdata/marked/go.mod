@@ -0,0 +1,3 @@
+module example.com/marked
+
+go 1.22
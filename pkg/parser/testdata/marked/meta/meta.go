@@ -0,0 +1,14 @@
+package meta
+
+// Info @GoasGlobal 标记的变量优先于 main 函数
+//
+// @GoasGlobal
+// @Title Meta
+// @Version 2.0
+var Info struct{}
+
+// Servers 同一个包中的多个来源合并
+//
+// @GoasGlobal
+// @Server https://api.example.com
+func Servers() {}
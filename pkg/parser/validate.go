@@ -0,0 +1,279 @@
+package parser
+
+import (
+	"go/types"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+// 校验规则对应的 format
+var validateFormats = map[string]string{
+	"email":         "email",
+	"url":           "uri",
+	"uri":           "uri",
+	"http_url":      "uri",
+	"uuid":          "uuid",
+	"uuid3":         "uuid",
+	"uuid4":         "uuid",
+	"uuid5":         "uuid",
+	"ipv4":          "ipv4",
+	"ipv6":          "ipv6",
+	"hostname":      "hostname",
+	"fqdn":          "hostname",
+	"base64":        "byte",
+	"jwt":           "jwt",
+	"hexcolor":      "color",
+	"mac":           "mac",
+	"cidr":          "cidr",
+	"hostname_port": "hostname-port",
+}
+
+// 校验规则对应的正则
+var validatePatterns = map[string]string{
+	"alpha":        `^[a-zA-Z]+$`,
+	"alphanum":     `^[a-zA-Z0-9]+$`,
+	"alphaunicode": `^[\p{L}]+$`,
+	"numeric":      `^[-+]?[0-9]+(?:\.[0-9]+)?$`,
+	"number":       `^[0-9]+$`,
+	"hexadecimal":  `^(0[xX])?[0-9a-fA-F]+$`,
+	"lowercase":    `^[^A-Z]*$`,
+	"uppercase":    `^[^a-z]*$`,
+	"e164":         `^\+[1-9]?[0-9]{7,14}$`,
+	"ascii":        `^[\x00-\x7F]*$`,
+}
+
+// lookupTag 获取 struct tag 中指定 key 的值
+func lookupTag(tag, key string) string {
+	return reflect.StructTag(tag).Get(key)
+}
+
+// applyValidation 将 validate (go-playground/validator) 和 binding (gin) tag 中的校验规则
+// 映射为 JSON Schema 约束，返回字段是否被标记为 required
+// 示例: validate:"required,min=1,max=64,email,oneof=a b"
+func applyValidation(schema *model.Schema, t types.Type, tag string) (required bool) {
+	var rules []string
+	for _, key := range []string{"validate", "binding"} {
+		if v := lookupTag(tag, key); v != "" && v != "-" {
+			rules = append(rules, strings.Split(v, ",")...)
+		}
+	}
+
+	target, targetType := schema, t
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		name, value, _ := strings.Cut(rule, "=")
+
+		switch {
+		case name == "required" && target == schema:
+			required = true
+		case name == "dive":
+			// dive 之后的规则作用于数组元素 / map 值
+			next := target.Items
+			if m, ok := target.AdditionalProperties.(*model.Schema); ok && next == nil {
+				next = m
+			}
+			elem := elemType(targetType)
+			if next == nil || elem == nil {
+				return required
+			}
+			target, targetType = next, elem
+		case strings.Contains(rule, "|"):
+			// 或规则无法准确映射，忽略
+		default:
+			applyRule(target, targetType, name, value)
+		}
+	}
+	return required
+}
+
+// applyRule 应用单条校验规则
+func applyRule(schema *model.Schema, t types.Type, name, value string) {
+	// $ref 的约束应写在被引用的 Schema 上
+	if schema.Ref != "" {
+		return
+	}
+
+	kind := validationKind(t)
+
+	switch name {
+	case "min", "max", "len", "gt", "gte", "lt", "lte":
+		applyBound(schema, kind, name, value)
+	case "oneof":
+		for _, v := range splitOneOf(value) {
+			if kind == kindNumber {
+				schema.Enum = append(schema.Enum, parseNumber(v))
+			} else {
+				schema.Enum = append(schema.Enum, v)
+			}
+		}
+	case "unique":
+		if kind == kindArray {
+			schema.UniqueItems = true
+		}
+	case "startswith":
+		addPattern(schema, "^"+regexp.QuoteMeta(value))
+	case "endswith":
+		addPattern(schema, regexp.QuoteMeta(value)+"$")
+	case "contains":
+		addPattern(schema, regexp.QuoteMeta(value))
+	case "datetime":
+		// datetime=2006-01-02 只包含日期部分
+		schema.Format = "date-time"
+		if value == "2006-01-02" {
+			schema.Format = "date"
+		}
+	default:
+		if format, ok := validateFormats[name]; ok {
+			schema.Format = format
+		} else if pattern, ok := validatePatterns[name]; ok {
+			addPattern(schema, pattern)
+		}
+	}
+}
+
+// addPattern 添加正则约束
+// 一个 Schema 只能有一个 pattern，多条规则 (如 startswith=a,endswith=z) 的正则放入 allOf，须同时满足
+func addPattern(schema *model.Schema, pattern string) {
+	if schema.Pattern == "" || schema.Pattern == pattern {
+		schema.Pattern = pattern
+		return
+	}
+	for _, sub := range schema.AllOf {
+		if sub.Pattern == pattern {
+			return
+		}
+	}
+	schema.AllOf = append(schema.AllOf, &model.Schema{Pattern: pattern})
+}
+
+// applyBound 应用长度 / 数值 / 数量限制
+func applyBound(schema *model.Schema, kind validationType, name, value string) {
+	if kind == kindNumber {
+		n := parseNumber(value)
+		switch name {
+		case "min", "gte":
+			schema.Minimum = n
+		case "max", "lte":
+			schema.Maximum = n
+		case "len":
+			schema.Minimum, schema.Maximum = n, n
+		case "gt":
+			schema.ExclusiveMinimum = n
+		case "lt":
+			schema.ExclusiveMaximum = n
+		}
+		return
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return
+	}
+	// 长度类约束没有 exclusive 形式，转换为闭区间
+	switch name {
+	case "gt":
+		n++
+	case "lt":
+		n--
+	}
+
+	var minPtr, maxPtr *int
+	switch kind {
+	case kindString:
+		minPtr, maxPtr = &schema.MinLength, &schema.MaxLength
+	case kindArray:
+		minPtr, maxPtr = &schema.MinItems, &schema.MaxItems
+	case kindObject:
+		minPtr, maxPtr = &schema.MinProperties, &schema.MaxProperties
+	default:
+		return
+	}
+
+	switch name {
+	case "min", "gte", "gt":
+		*minPtr = n
+	case "max", "lte", "lt":
+		*maxPtr = n
+	case "len":
+		*minPtr, *maxPtr = n, n
+	}
+}
+
+// validationType 校验规则作用的值类别
+type validationType int
+
+const (
+	kindOther validationType = iota
+	kindString
+	kindNumber
+	kindArray
+	kindObject
+)
+
+// validationKind 判断字段类型属于哪一类 (决定 min/max 映射为长度、数值还是数量)
+func validationKind(t types.Type) validationType {
+	if t == nil {
+		return kindOther
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return kindString
+		case u.Info()&types.IsNumeric != 0:
+			return kindNumber
+		}
+	case *types.Slice, *types.Array:
+		return kindArray
+	case *types.Map:
+		return kindObject
+	}
+	return kindOther
+}
+
+// elemType 获取数组 / map 的元素类型
+func elemType(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		return u.Elem()
+	case *types.Array:
+		return u.Elem()
+	case *types.Map:
+		return u.Elem()
+	}
+	return nil
+}
+
+// splitOneOf 拆分 oneof 的候选值，支持单引号包裹含空格的值
+// 输入: "a b 'c d'" -> ["a", "b", "c d"]
+func splitOneOf(s string) []string {
+	var values []string
+	for _, v := range splitParams(s) {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// parseNumber 将字符串解析为整数或浮点数，失败时原样返回
+func parseNumber(s string) any {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
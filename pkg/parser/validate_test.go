@@ -0,0 +1,132 @@
+package parser
+
+import (
+	"go/types"
+	"reflect"
+	"testing"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+func TestApplyValidation(t *testing.T) {
+	var (
+		str     = types.Typ[types.String]
+		integer = types.Typ[types.Int]
+		strs    = types.NewSlice(types.Typ[types.String])
+		dict    = types.NewMap(types.Typ[types.String], types.Typ[types.Int])
+	)
+	stringSchema := func() *model.Schema { return &model.Schema{Type: "string"} }
+	intSchema := func() *model.Schema { return &model.Schema{Type: "integer"} }
+	arraySchema := func() *model.Schema { return &model.Schema{Type: "array", Items: &model.Schema{Type: "string"}} }
+
+	tests := []struct {
+		name         string
+		typ          types.Type
+		schema       *model.Schema
+		tag          string
+		want         *model.Schema
+		wantRequired bool
+	}{
+		{
+			name:         "required 与字符串长度",
+			typ:          str,
+			schema:       stringSchema(),
+			tag:          `validate:"required,min=1,max=64"`,
+			want:         &model.Schema{Type: "string", MinLength: 1, MaxLength: 64},
+			wantRequired: true,
+		},
+		{
+			name:   "数值范围",
+			typ:    integer,
+			schema: intSchema(),
+			tag:    `validate:"gte=1,lt=100"`,
+			want:   &model.Schema{Type: "integer", Minimum: int64(1), ExclusiveMaximum: int64(100)},
+		},
+		{
+			name:   "字符串的 exclusive 长度转换为闭区间",
+			typ:    str,
+			schema: stringSchema(),
+			tag:    `validate:"gt=2,lt=10"`,
+			want:   &model.Schema{Type: "string", MinLength: 3, MaxLength: 9},
+		},
+		{
+			name:   "format 与 pattern",
+			typ:    str,
+			schema: stringSchema(),
+			tag:    `validate:"email,alphanum"`,
+			want:   &model.Schema{Type: "string", Format: "email", Pattern: `^[a-zA-Z0-9]+$`},
+		},
+		{
+			name:   "多条正则规则放入 allOf",
+			typ:    str,
+			schema: stringSchema(),
+			tag:    `validate:"startswith=a.,endswith=z,contains=m,startswith=a."`,
+			want: &model.Schema{Type: "string", Pattern: `^a\.`, AllOf: []*model.Schema{
+				{Pattern: `z$`},
+				{Pattern: `m`},
+			}},
+		},
+		{
+			name:   "字符串 oneof",
+			typ:    str,
+			schema: stringSchema(),
+			tag:    `validate:"oneof=active 'on hold'"`,
+			want:   &model.Schema{Type: "string", Enum: []any{"active", "on hold"}},
+		},
+		{
+			name:   "数值 oneof",
+			typ:    integer,
+			schema: intSchema(),
+			tag:    `validate:"oneof=1 2"`,
+			want:   &model.Schema{Type: "integer", Enum: []any{int64(1), int64(2)}},
+		},
+		{
+			name:   "数组数量与 dive 之后的元素规则",
+			typ:    strs,
+			schema: arraySchema(),
+			tag:    `validate:"min=1,unique,dive,max=8"`,
+			want:   &model.Schema{Type: "array", MinItems: 1, UniqueItems: true, Items: &model.Schema{Type: "string", MaxLength: 8}},
+		},
+		{
+			name:   "map 的属性数量",
+			typ:    dict,
+			schema: &model.Schema{Type: "object"},
+			tag:    `validate:"max=5"`,
+			want:   &model.Schema{Type: "object", MaxProperties: 5},
+		},
+		{
+			name:         "gin binding tag",
+			typ:          str,
+			schema:       stringSchema(),
+			tag:          `binding:"required,len=6"`,
+			want:         &model.Schema{Type: "string", MinLength: 6, MaxLength: 6},
+			wantRequired: true,
+		},
+		{
+			name:   "或规则被忽略",
+			typ:    str,
+			schema: stringSchema(),
+			tag:    `validate:"email|url"`,
+			want:   &model.Schema{Type: "string"},
+		},
+		{
+			name:   "dive 之后的 required 不作用于字段本身",
+			typ:    strs,
+			schema: arraySchema(),
+			tag:    `validate:"dive,required"`,
+			want:   arraySchema(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			required := applyValidation(tt.schema, tt.typ, tt.tag)
+			if required != tt.wantRequired {
+				t.Errorf("required = %v, want %v", required, tt.wantRequired)
+			}
+			if !reflect.DeepEqual(tt.schema, tt.want) {
+				t.Errorf("schema = %+v, want %+v", tt.schema, tt.want)
+			}
+		})
+	}
+}
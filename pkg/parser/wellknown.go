@@ -0,0 +1,148 @@
+package parser
+
+import (
+	"encoding/json"
+	"go/types"
+	"slices"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+// wellKnownTypes 常见标准库 / 第三方类型的 Schema
+// 这些类型的 JSON 表现与其底层结构不同 (如 time.Time 是 RFC 3339 字符串)，不能按底层类型解析
+// Key: 全限定类型名 (包路径.类型名)
+var wellKnownTypes = map[string]func() *model.Schema{
+	// 标准库
+	"time.Time":                   func() *model.Schema { return &model.Schema{Type: "string", Format: "date-time"} },
+	"time.Duration":               func() *model.Schema { return &model.Schema{Type: "integer", Format: "int64"} },
+	"encoding/json.RawMessage":    func() *model.Schema { return &model.Schema{} },
+	"encoding/json.Number":        func() *model.Schema { return &model.Schema{Type: "number"} },
+	"math/big.Int":                func() *model.Schema { return &model.Schema{Type: "integer"} },
+	"math/big.Float":              func() *model.Schema { return &model.Schema{Type: "number"} },
+	"net.IP":                      func() *model.Schema { return &model.Schema{Type: "string"} },
+	"net/netip.Addr":              func() *model.Schema { return &model.Schema{Type: "string"} },
+	"mime/multipart.FileHeader":   func() *model.Schema { return &model.Schema{Type: "string", Format: "binary"} },
+	"database/sql.NullString":     func() *model.Schema { return nullableSchema(&model.Schema{Type: "string"}) },
+	"database/sql.NullBool":       func() *model.Schema { return nullableSchema(&model.Schema{Type: "boolean"}) },
+	"database/sql.NullByte":       func() *model.Schema { return nullableSchema(&model.Schema{Type: "integer", Format: "int32"}) },
+	"database/sql.NullInt16":      func() *model.Schema { return nullableSchema(&model.Schema{Type: "integer", Format: "int32"}) },
+	"database/sql.NullInt32":      func() *model.Schema { return nullableSchema(&model.Schema{Type: "integer", Format: "int32"}) },
+	"database/sql.NullInt64":      func() *model.Schema { return nullableSchema(&model.Schema{Type: "integer", Format: "int64"}) },
+	"database/sql.NullFloat64":    func() *model.Schema { return nullableSchema(&model.Schema{Type: "number", Format: "double"}) },
+	"database/sql.NullTime":       func() *model.Schema { return nullableSchema(&model.Schema{Type: "string", Format: "date-time"}) },
+	"github.com/google/uuid.UUID": func() *model.Schema { return &model.Schema{Type: "string", Format: "uuid"} },
+	"github.com/google/uuid.NullUUID": func() *model.Schema {
+		return nullableSchema(&model.Schema{Type: "string", Format: "uuid"})
+	},
+	"github.com/gofrs/uuid.UUID":            func() *model.Schema { return &model.Schema{Type: "string", Format: "uuid"} },
+	"github.com/gofrs/uuid/v5.UUID":         func() *model.Schema { return &model.Schema{Type: "string", Format: "uuid"} },
+	"github.com/satori/go.uuid.UUID":        func() *model.Schema { return &model.Schema{Type: "string", Format: "uuid"} },
+	"github.com/shopspring/decimal.Decimal": func() *model.Schema { return &model.Schema{Type: "string", Format: "decimal"} },
+	"github.com/shopspring/decimal.NullDecimal": func() *model.Schema {
+		return nullableSchema(&model.Schema{Type: "string", Format: "decimal"})
+	},
+}
+
+// namedOrAlias 命名类型或类型别名 (*types.Named / *types.Alias)
+type namedOrAlias interface {
+	Obj() *types.TypeName
+	TypeArgs() *types.TypeList
+}
+
+// knownTypeSchema 查找用户配置的类型覆盖或内置的常见类型 Schema
+// 用户配置 (Options.TypeOverrides) 优先于内置表，未命中时返回 nil
+func (p *Processor) knownTypeSchema(named namedOrAlias) *model.Schema {
+	obj := named.Obj()
+	if obj.Pkg() == nil {
+		return nil
+	}
+	fullName := obj.Pkg().Path() + "." + obj.Name()
+
+	if schema, ok := p.opts.TypeOverrides[fullName]; ok {
+		return cloneSchema(schema)
+	}
+
+	if newSchema, ok := wellKnownTypes[fullName]; ok {
+		return newSchema()
+	}
+
+	// database/sql.Null[T] (Go 1.22+)
+	if fullName == "database/sql.Null" && named.TypeArgs().Len() == 1 {
+		return nullableSchema(p.typeToSchema(named.TypeArgs().At(0)))
+	}
+
+	return nil
+}
+
+// isByteSlice 检查是否是 []byte (encoding/json 将其编码为 base64 字符串)
+func isByteSlice(t *types.Slice) bool {
+	basic, ok := t.Elem().Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Uint8
+}
+
+// byteSliceSchema []byte 对应的 Schema
+func byteSliceSchema() *model.Schema {
+	return &model.Schema{Type: "string", Format: "byte", ContentEncoding: "base64"}
+}
+
+// nullableSchema 允许 Schema 取 null 值 (OpenAPI 3.1+ 写法)
+// - 普通类型:     type: ["string", "null"]，带枚举时枚举值中加入 null
+// - $ref / 组合:  anyOf: [{$ref: ...}, {type: "null"}]
+// - 无约束 Schema: 本身已允许 null，保持不变
+func nullableSchema(schema *model.Schema) *model.Schema {
+	composed := len(schema.AllOf) > 0 || len(schema.AnyOf) > 0 || len(schema.OneOf) > 0
+	if schema.Ref != "" || (schema.Type == nil && composed) {
+		return &model.Schema{
+			AnyOf: []*model.Schema{schema, {Type: "null"}},
+		}
+	}
+
+	// 手动构造的 Schema 可能使用 []any 表示类型数组
+	if types, ok := schema.Type.([]any); ok {
+		var names []string
+		for _, t := range types {
+			if name, ok := t.(string); ok {
+				names = append(names, name)
+			}
+		}
+		if len(names) == len(types) {
+			schema.Type = names
+		}
+	}
+
+	switch t := schema.Type.(type) {
+	case string:
+		if t == "" || t == "null" {
+			return schema
+		}
+		schema.Type = []string{t, "null"}
+	case []string:
+		if slices.Contains(t, "null") {
+			return schema
+		}
+		schema.Type = append(t, "null")
+	default:
+		return schema
+	}
+
+	if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, any(nil)) {
+		schema.Enum = append(schema.Enum, nil)
+	}
+	return schema
+}
+
+// cloneSchema 深拷贝 Schema，避免共享的配置 Schema 被后续处理 (描述、校验规则) 修改
+func cloneSchema(schema *model.Schema) *model.Schema {
+	if schema == nil {
+		return &model.Schema{}
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return &model.Schema{}
+	}
+	clone := &model.Schema{}
+	if err := json.Unmarshal(data, clone); err != nil {
+		return &model.Schema{}
+	}
+	return clone
+}
@@ -0,0 +1,72 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/promonkeyli/goas/pkg/model"
+)
+
+func TestNullableSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *model.Schema
+		want   *model.Schema
+	}{
+		{"普通类型", &model.Schema{Type: "string"}, &model.Schema{Type: []string{"string", "null"}}},
+		{"类型数组", &model.Schema{Type: []string{"string", "integer"}}, &model.Schema{Type: []string{"string", "integer", "null"}}},
+		{"已允许 null", &model.Schema{Type: []string{"string", "null"}}, &model.Schema{Type: []string{"string", "null"}}},
+		// JSON 解码或手动构造的类型数组为 []any
+		{"[]any 类型数组", &model.Schema{Type: []any{"string", "integer"}}, &model.Schema{Type: []string{"string", "integer", "null"}}},
+		{"带枚举", &model.Schema{Type: "string", Enum: []any{"a"}}, &model.Schema{Type: []string{"string", "null"}, Enum: []any{"a", nil}}},
+		{"无约束 Schema", &model.Schema{}, &model.Schema{}},
+		{"$ref", &model.Schema{Ref: "#/components/schemas/User"}, &model.Schema{AnyOf: []*model.Schema{{Ref: "#/components/schemas/User"}, {Type: "null"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nullableSchema(tt.schema); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("nullableSchema() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWellKnownTypes(t *testing.T) {
+	openapi, _ := parseApp(t, Options{})
+	wallet := componentSchema(t, openapi, "Wallet")
+
+	tests := []struct {
+		field string
+		want  *model.Schema
+	}{
+		{"created", &model.Schema{Type: "string", Format: "date-time"}},
+		{"raw", &model.Schema{Type: "string", Format: "byte", ContentEncoding: "base64"}},
+	}
+
+	for _, tt := range tests {
+		prop, _ := wallet.Properties.Get(tt.field)
+		if !reflect.DeepEqual(prop, tt.want) {
+			t.Errorf("%s = %+v, want %+v", tt.field, prop, tt.want)
+		}
+	}
+}
+
+func TestTypeOverrides(t *testing.T) {
+	override := &model.Schema{Type: []string{"string", "integer"}}
+	openapi, _ := parseApp(t, Options{
+		TypeOverrides: map[string]*model.Schema{"example.com/app/models.Money": override},
+	})
+
+	// 指针字段在覆盖的类型数组上追加 null，不修改配置中的 Schema
+	balance, _ := componentSchema(t, openapi, "Wallet").Properties.Get("balance")
+	if want := []string{"string", "integer", "null"}; !reflect.DeepEqual(balance.Type, want) {
+		t.Errorf("balance 的类型 = %#v, want %#v", balance.Type, want)
+	}
+	if want := []string{"string", "integer"}; !reflect.DeepEqual(override.Type, want) {
+		t.Errorf("配置中的 Schema 被修改为 %#v", override.Type)
+	}
+	if openapi.Components.Schemas["Money"] != nil {
+		t.Errorf("覆盖的类型不应生成 Components Schema")
+	}
+}